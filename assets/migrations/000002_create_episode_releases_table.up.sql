@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS episode_releases (
+    episode_id TEXT PRIMARY KEY,
+    anime_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    image TEXT NOT NULL DEFAULT '',
+    episode_number REAL NOT NULL,
+    sub_or_dub TEXT NOT NULL,
+    released_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS episode_releases_anime_id_idx ON episode_releases (anime_id);
+CREATE INDEX IF NOT EXISTS episode_releases_released_at_idx ON episode_releases (released_at);
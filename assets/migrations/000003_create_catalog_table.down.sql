@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS catalog_au;
+DROP TRIGGER IF EXISTS catalog_ad;
+DROP TRIGGER IF EXISTS catalog_ai;
+DROP TABLE IF EXISTS catalog_fts_vocab;
+DROP TABLE IF EXISTS catalog_fts;
+DROP TABLE IF EXISTS catalog;
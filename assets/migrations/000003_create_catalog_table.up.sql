@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS catalog (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    source TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    alt_titles TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    year INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL,
+    UNIQUE (kind, source, source_id)
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS catalog_fts USING fts5(
+    title,
+    alt_titles,
+    description,
+    content = 'catalog',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS catalog_fts_vocab USING fts5vocab(catalog_fts, 'row');
+
+CREATE TRIGGER IF NOT EXISTS catalog_ai AFTER INSERT ON catalog BEGIN
+    INSERT INTO catalog_fts (rowid, title, alt_titles, description) VALUES (new.id, new.title, new.alt_titles, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS catalog_ad AFTER DELETE ON catalog BEGIN
+    INSERT INTO catalog_fts (catalog_fts, rowid, title, alt_titles, description) VALUES ('delete', old.id, old.title, old.alt_titles, old.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS catalog_au AFTER UPDATE ON catalog BEGIN
+    INSERT INTO catalog_fts (catalog_fts, rowid, title, alt_titles, description) VALUES ('delete', old.id, old.title, old.alt_titles, old.description);
+    INSERT INTO catalog_fts (rowid, title, alt_titles, description) VALUES (new.id, new.title, new.alt_titles, new.description);
+END;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS anime_mappings (
+    provider TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    anilist_id INTEGER NOT NULL DEFAULT 0,
+    mal_id INTEGER NOT NULL DEFAULT 0,
+    kitsu_id INTEGER NOT NULL DEFAULT 0,
+    confidence REAL NOT NULL DEFAULT 0,
+    manual BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (provider, provider_id)
+);
+
+CREATE INDEX IF NOT EXISTS anime_mappings_anilist_id_idx ON anime_mappings (anilist_id);
+CREATE INDEX IF NOT EXISTS anime_mappings_mal_id_idx ON anime_mappings (mal_id);
+CREATE INDEX IF NOT EXISTS anime_mappings_kitsu_id_idx ON anime_mappings (kitsu_id);
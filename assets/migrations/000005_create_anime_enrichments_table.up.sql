@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS anime_enrichments (
+    anilist_id INTEGER PRIMARY KEY,
+    data TEXT NOT NULL,
+    fetched_at DATETIME NOT NULL
+);
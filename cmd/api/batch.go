@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"miruchigawa.moe/restapi/internal/request"
+	"miruchigawa.moe/restapi/internal/response"
+	"miruchigawa.moe/restapi/internal/validator"
+)
+
+const maxBatchRequests = 50
+
+type batchRequest struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Query  batchQuery `json:"query"`
+}
+
+// batchQuery is the query string of a sub-request. Each parameter is either
+// a string or, to repeat it, a list of strings.
+type batchQuery map[string][]string
+
+func (q *batchQuery) UnmarshalJSON(data []byte) error {
+	var params map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &params)
+	if err != nil {
+		return err
+	}
+
+	*q = make(batchQuery, len(params))
+	for key, raw := range params {
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("query parameter %q must be a string or a list of strings", key)
+			}
+			values = []string{value}
+		}
+
+		(*q)[key] = values
+	}
+
+	return nil
+}
+
+type batchResponse struct {
+	Status int `json:"status"`
+	Body   any `json:"body"`
+}
+
+// batch runs each sub-request against root, at most config.batch.parallelism
+// at a time, and responds with their statuses and bodies in the same order.
+// Sub-requests go through the full middleware chain individually, carrying
+// the caller's headers and address, so each one is logged as a request of
+// its own. There is no rate limiting or quota yet for them to count against.
+func (app *application) batch(root http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input []batchRequest
+
+		err := request.DecodeJSONStrict(w, r, &input)
+		if err != nil {
+			app.badRequest(w, r, err)
+			return
+		}
+
+		v := validator.Validator{}
+		v.Check(validator.Between(len(input), 1, maxBatchRequests), fmt.Sprintf("batch must contain between 1 and %d requests", maxBatchRequests))
+
+		for i := range input {
+			input[i].Method = strings.ToUpper(strings.TrimSpace(input[i].Method))
+			if input[i].Method == "" {
+				input[i].Method = http.MethodGet
+			}
+
+			key := fmt.Sprintf("%d", i)
+			v.CheckField(validator.In(input[i].Method, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete), key+".method", "method must be one of GET, POST, PUT, DELETE")
+			v.CheckField(strings.HasPrefix(input[i].Path, "/"), key+".path", "path must start with /")
+			v.CheckField(!strings.ContainsAny(input[i].Path, "?#"), key+".path", "path must not contain a query string or fragment")
+		}
+
+		if v.HasErrors() {
+			app.failedValidation(w, r, v)
+			return
+		}
+
+		results := make([]batchResponse, len(input))
+		sem := make(chan struct{}, max(app.config.batch.parallelism, 1))
+
+		var wg sync.WaitGroup
+		for i, sub := range input {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func(i int, sub batchRequest) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				results[i] = app.batchDispatch(root, r, sub)
+			}(i, sub)
+		}
+		wg.Wait()
+
+		err = response.Data(w, r, http.StatusOK, results)
+		if err != nil {
+			app.serverError(w, r, err)
+		}
+	}
+}
+
+func (app *application) batchDispatch(root http.Handler, r *http.Request, sub batchRequest) batchResponse {
+	u := &url.URL{Path: sub.Path, RawQuery: url.Values(sub.Query).Encode()}
+
+	subRequest, err := http.NewRequestWithContext(r.Context(), sub.Method, u.String(), http.NoBody)
+	if err != nil {
+		return batchResponse{Status: http.StatusBadRequest, Body: err.Error()}
+	}
+
+	// Sub-responses are always asked for as uncompressed JSON so they can
+	// be decoded.
+	subRequest.Header = r.Header.Clone()
+	subRequest.Header.Set("Accept", "application/json")
+	subRequest.Header.Del("Accept-Encoding")
+	subRequest.Header.Del("Content-Type")
+	subRequest.Header.Del("Content-Length")
+	subRequest.Host = r.Host
+	subRequest.RemoteAddr = r.RemoteAddr
+	subRequest.Proto, subRequest.ProtoMajor, subRequest.ProtoMinor = r.Proto, r.ProtoMajor, r.ProtoMinor
+
+	rec := httptest.NewRecorder()
+	root.ServeHTTP(rec, subRequest)
+
+	result := batchResponse{Status: rec.Code}
+	if rec.Body.Len() == 0 {
+		return result
+	}
+
+	// Decoded bodies are re-encoded in whatever format the batch response
+	// was negotiated as. Anything that isn't JSON is passed on as text.
+	mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		err := json.Unmarshal(rec.Body.Bytes(), &result.Body)
+		if err == nil {
+			return result
+		}
+	}
+
+	result.Body = rec.Body.String()
+
+	return result
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/database"
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+	mangaModels "miruchigawa.moe/restapi/internal/models/manga"
+	searchModels "miruchigawa.moe/restapi/internal/models/search"
+)
+
+const (
+	catalogSourceAnitaku  = "anitaku"
+	catalogSourceMangadex = "mangadex"
+
+	catalogMaxAge = 5 * time.Minute
+)
+
+func (app *application) catalogAnimeResults(results []animeModels.AnimeResult) error {
+	entries := make([]database.CatalogEntry, 0, len(results))
+
+	for _, result := range results {
+		entries = append(entries, database.CatalogEntry{
+			Kind:     string(searchModels.ANIME),
+			Source:   catalogSourceAnitaku,
+			SourceID: result.ID,
+			Title:    result.Title,
+			Image:    result.Image,
+			Year:     parseYear(result.ReleaseDate),
+		})
+	}
+
+	return app.db.UpsertCatalogEntries(entries)
+}
+
+func (app *application) catalogAnimeInfo(info *animeModels.AnimeInfo) error {
+	entry := database.CatalogEntry{
+		Kind:        string(searchModels.ANIME),
+		Source:      catalogSourceAnitaku,
+		SourceID:    info.ID,
+		Title:       info.Title,
+		AltTitles:   info.OtherName,
+		Description: info.Description,
+		Image:       info.Image,
+		Year:        parseYear(info.ReleaseDate),
+	}
+
+	return app.db.UpsertCatalogEntries([]database.CatalogEntry{entry})
+}
+
+func (app *application) catalogMangaResults(results []mangaModels.MangaInfo) error {
+	entries := make([]database.CatalogEntry, 0, len(results))
+
+	for _, result := range results {
+		entries = append(entries, database.CatalogEntry{
+			Kind:        string(searchModels.MANGA),
+			Source:      catalogSourceMangadex,
+			SourceID:    result.ID,
+			Title:       result.Title,
+			AltTitles:   flattenAltTitles(result.AltTitles),
+			Description: result.Description,
+			Image:       result.Image,
+			Year:        result.ReleaseDate,
+		})
+	}
+
+	return app.db.UpsertCatalogEntries(entries)
+}
+
+func (app *application) catalogSearchResults(results []searchModels.Result) error {
+	entries := make([]database.CatalogEntry, 0, len(results))
+
+	for _, result := range results {
+		entries = append(entries, database.CatalogEntry{
+			Kind:     string(result.Kind),
+			Source:   result.Source,
+			SourceID: result.ID,
+			Title:    result.Title,
+			Image:    result.Image,
+			Year:     result.Year,
+		})
+	}
+
+	return app.db.UpsertCatalogEntries(entries)
+}
+
+func (app *application) localAnimeSearch(query string, page int) (*animeModels.SearchResult, error) {
+	const pageSize = 20
+
+	entries, err := app.db.SearchCatalog(string(searchModels.ANIME), query, pageSize+1, pageSize*(page-1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &animeModels.SearchResult{
+		CurrentPage: page,
+		HasNextPage: len(entries) > pageSize,
+		Results:     []animeModels.AnimeResult{},
+	}
+
+	for i, entry := range entries {
+		if i == pageSize {
+			break
+		}
+
+		result.Results = append(result.Results, animeModels.AnimeResult{
+			ID:          entry.SourceID,
+			Title:       entry.Title,
+			URL:         anime.CategoryURL(entry.SourceID),
+			Image:       entry.Image,
+			ReleaseDate: formatYear(entry.Year),
+			SubOrDub:    anime.DetermineSubOrDub(entry.Title),
+		})
+	}
+
+	return result, nil
+}
+
+func (app *application) localMangaSearch(query string, page, limit int) (*mangaModels.SearchResults, error) {
+	entries, err := app.db.SearchCatalog(string(searchModels.MANGA), query, limit+1, limit*(page-1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mangaModels.SearchResults{
+		CurrentPage: page,
+		HasNextPage: len(entries) > limit,
+		Results:     []mangaModels.MangaInfo{},
+	}
+
+	for i, entry := range entries {
+		if i == limit {
+			break
+		}
+
+		result.Results = append(result.Results, mangaModels.MangaInfo{
+			ID:          entry.SourceID,
+			Title:       entry.Title,
+			Description: entry.Description,
+			ReleaseDate: entry.Year,
+			Image:       entry.Image,
+		})
+	}
+
+	return result, nil
+}
+
+func catalogResult(entry database.CatalogEntry) searchModels.Result {
+	return searchModels.Result{
+		Kind:   searchModels.Kind(entry.Kind),
+		Source: entry.Source,
+		ID:     entry.SourceID,
+		Title:  entry.Title,
+		Image:  entry.Image,
+		Year:   entry.Year,
+		Score:  -entry.Rank,
+	}
+}
+
+func flattenAltTitles(altTitles []map[string]string) string {
+	var titles []string
+
+	for _, alt := range altTitles {
+		for _, title := range alt {
+			titles = append(titles, title)
+		}
+	}
+
+	return strings.Join(titles, " | ")
+}
+
+func parseYear(s string) int {
+	s = strings.TrimSpace(s)
+	if len(s) >= 4 {
+		s = s[:4]
+	}
+
+	year, _ := strconv.Atoi(s)
+	return year
+}
+
+func formatYear(year int) string {
+	if year == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", year)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/archive"
+	"miruchigawa.moe/restapi/internal/funcs/manga"
+)
+
+const chapterDownloadTimeout = 5 * time.Minute
+
+// chapterDownloadRoute names the chapter download route, whose format
+// parameter may be an archive format instead of a response format.
+const chapterDownloadRoute = "manga-chapter-download"
+
+type chapterFormat struct {
+	contentType string
+	extension   string
+	write       func(w io.Writer, book archive.Book) error
+}
+
+var chapterFormats = map[string]chapterFormat{
+	"cbz":  {contentType: "application/vnd.comicbook+zip", extension: ".cbz", write: archive.WriteCBZ},
+	"epub": {contentType: "application/epub+zip", extension: ".epub", write: archive.WriteEPUB},
+}
+
+var rgxUnsafeFileName = regexp.MustCompile(`[^\p{L}\p{N} ._()\-]+`)
+
+// chapterBook downloads every page of the given chapters and collects them
+// into a book, in the order the chapters were given. progress is called with
+// the fraction of chapters done after each one.
+func (app *application) chapterBook(ctx context.Context, ids []string, volume string, dataSaver bool, progress func(float64)) (archive.Book, error) {
+	var book archive.Book
+
+	for i, id := range ids {
+		chapter, err := manga.Chapter(ctx, id)
+		if err != nil {
+			return book, err
+		}
+
+		urls, err := manga.PageURLs(ctx, id, dataSaver)
+		if err != nil {
+			return book, err
+		}
+
+		pages, err := manga.FetchPages(ctx, urls)
+		if err != nil {
+			return book, err
+		}
+
+		if i == 0 {
+			book.ID = "urn:uuid:" + chapter.ID
+			book.Series = chapter.MangaTitle
+			book.Language = chapter.Language
+			book.Groups = chapter.Groups
+			book.Volume = chapter.Volume
+			book.Number = chapter.Chapter
+			book.Title = chapterTitle(chapter.MangaTitle, chapter.Volume, chapter.Chapter, chapter.Title)
+			book.Web = "https://mangadex.org/chapter/" + chapter.ID
+		}
+
+		book.Chapters = append(book.Chapters, archive.Chapter{
+			Title: chapterTitle("", "", chapter.Chapter, chapter.Title),
+			Pages: pages,
+		})
+
+		if progress != nil {
+			progress(float64(i+1) / float64(len(ids)))
+		}
+	}
+
+	if len(ids) > 1 {
+		book.Number = ""
+		book.Web = ""
+		book.Title = chapterTitle(book.Series, volume, "", "")
+	}
+
+	return book, nil
+}
+
+func chapterTitle(series, volume, chapter, title string) string {
+	var parts []string
+
+	if series != "" {
+		parts = append(parts, series)
+	}
+	if volume != "" {
+		parts = append(parts, "Vol. "+volume)
+	}
+	if chapter != "" {
+		parts = append(parts, "Ch. "+chapter)
+	}
+	if title != "" {
+		parts = append(parts, title)
+	}
+
+	return strings.Join(parts, " - ")
+}
+
+func chapterFileName(book archive.Book, format chapterFormat) string {
+	name := strings.TrimSpace(rgxUnsafeFileName.ReplaceAllString(book.Title, ""))
+	if name == "" {
+		name = "chapter"
+	}
+
+	return fmt.Sprintf("%s%s", name, format.extension)
+}
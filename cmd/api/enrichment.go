@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/funcs/anilist"
+	"miruchigawa.moe/restapi/internal/funcs/mapping"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+const enrichmentTTL = 24 * time.Hour
+
+// enrichAnimeInfo merges AniList metadata into info. Anime without a
+// confirmed mapping are left as they are.
+func (app *application) enrichAnimeInfo(info *animeModels.AnimeInfo) error {
+	m, err := app.resolveAnimeMapping(mapping.ProviderAnitaku, info.ID, info)
+	if err != nil {
+		return err
+	}
+
+	if !m.Confirmed || m.AniListID == 0 {
+		return nil
+	}
+
+	enrichment := &animeModels.Enrichment{}
+
+	cached, found, err := app.db.GetAnimeEnrichment(m.AniListID)
+	if err != nil {
+		return err
+	}
+
+	if found && time.Since(cached.FetchedAt) < enrichmentTTL {
+		err := json.Unmarshal([]byte(cached.Data), enrichment)
+		if err != nil {
+			return err
+		}
+	} else {
+		enrichment, err = anilist.Enrichment(m.AniListID)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(enrichment)
+		if err != nil {
+			return err
+		}
+
+		err = app.db.UpsertAnimeEnrichment(m.AniListID, string(data))
+		if err != nil {
+			return err
+		}
+	}
+
+	if enrichment.MalID == 0 {
+		enrichment.MalID = m.MalID
+	}
+
+	if info.Image == "" {
+		info.Image = enrichment.CoverImage
+	}
+
+	info.Enrichment = enrichment
+	return nil
+}
+
+// enrichAndCatalogAnimeInfo enriches info when asked to and then adds it to
+// the catalog in the background. Enrichment has to finish first since it can
+// fill in info.Image, which the catalog task reads.
+func (app *application) enrichAndCatalogAnimeInfo(r *http.Request, info *animeModels.AnimeInfo, enriched bool) {
+	if enriched {
+		err := app.enrichAnimeInfo(info)
+		if err != nil {
+			app.logger.Warn("anime enrichment failed", "id", info.ID, "error", err.Error())
+		}
+	}
+
+	app.backgroundTask(r, func() error {
+		return app.catalogAnimeInfo(info)
+	})
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
 	"strings"
 
+	"miruchigawa.moe/restapi/internal/funcs/downloader"
 	"miruchigawa.moe/restapi/internal/response"
 	"miruchigawa.moe/restapi/internal/validator"
 )
@@ -40,7 +42,7 @@ func (app *application) reportServerError(r *http.Request, err error) {
 func (app *application) errorMessage(w http.ResponseWriter, r *http.Request, status int, message string, headers http.Header) {
 	message = strings.ToUpper(message[:1]) + message[1:]
 
-	err := response.JSONWithHeaders(w, status, map[string]string{"Status": "ERROR", "Message": message}, headers)
+	err := response.Failure(w, r, status, response.Error{Code: response.ErrorCode(status), Message: message}, nil, headers)
 	if err != nil {
 		app.reportServerError(r, err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -68,12 +70,39 @@ func (app *application) badRequest(w http.ResponseWriter, r *http.Request, err e
 	app.errorMessage(w, r, http.StatusBadRequest, err.Error(), nil)
 }
 
+func (app *application) invalidAuthenticationToken(w http.ResponseWriter, r *http.Request) {
+	headers := make(http.Header)
+	headers.Set("WWW-Authenticate", "Bearer")
+
+	app.errorMessage(w, r, http.StatusUnauthorized, "Invalid or missing authentication token", headers)
+}
+
+// downloaderError reports errors from resolving a download link, telling
+// links which can't be downloaded apart from failures on our side.
+func (app *application) downloaderError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, downloader.ErrPasswordProtected), errors.Is(err, downloader.ErrAccessDenied):
+		app.errorMessage(w, r, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, downloader.ErrFileDeleted):
+		app.errorMessage(w, r, http.StatusGone, err.Error(), nil)
+	default:
+		app.serverError(w, r, err)
+	}
+}
+
 func (app *application) failedValidation(w http.ResponseWriter, r *http.Request, v validator.Validator) {
-	data := map[string]any{
-		"Status":  "ERROR",
-		"Message": v,
+	message := "One or more fields are invalid"
+	if len(v.Errors) > 0 {
+		message = strings.Join(v.Errors, "; ")
 	}
-	err := response.JSON(w, http.StatusUnprocessableEntity, data)
+
+	e := response.Error{
+		Code:    "validation_failed",
+		Message: message,
+		Fields:  v.FieldErrors,
+	}
+
+	err := response.Failure(w, r, http.StatusUnprocessableEntity, e, v, nil)
 	if err != nil {
 		app.serverError(w, r, err)
 	}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	"miruchigawa.moe/restapi/internal/funcs/downloader"
+	"miruchigawa.moe/restapi/internal/funcs/manga"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+	downloaderModels "miruchigawa.moe/restapi/internal/models/downloader"
+	mangaModels "miruchigawa.moe/restapi/internal/models/manga"
+	restapiv1 "miruchigawa.moe/restapi/internal/rpc/restapi/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+func (app *application) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(app.grpcLogAccess, app.grpcRecoverPanic, app.grpcAuthenticate))
+
+	restapiv1.RegisterAnimeServiceServer(srv, &animeService{app: app})
+	restapiv1.RegisterMangaServiceServer(srv, &mangaService{app: app})
+	restapiv1.RegisterDownloaderServiceServer(srv, &downloaderService{app: app})
+	reflection.Register(srv)
+
+	return srv
+}
+
+func (app *application) grpcLogAccess(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+	}
+
+	userAttrs := slog.Group("user", "ip", ip)
+	requestAttrs := slog.Group("request", "method", info.FullMethod)
+	responseAttrs := slog.Group("response", "code", status.Code(err).String(), "duration", time.Since(start))
+
+	app.logger.Info("grpc", userAttrs, requestAttrs, responseAttrs)
+	return resp, err
+}
+
+func (app *application) grpcRecoverPanic(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = app.grpcError(ctx, fmt.Errorf("%s", rec))
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// grpcAuthenticate requires one of the client tokens as a bearer token in the
+// authorization metadata, as the WebSocket API does, whenever any are
+// configured. Reflection is left open so grpcurl can list the services.
+func (app *application) grpcAuthenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if len(app.config.clients.tokens) == 0 || strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && app.validClientToken(token) {
+			return handler(ctx, req)
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "Invalid or missing authentication token")
+}
+
+// grpcRequest stands in for the HTTP request when reporting errors and
+// running background tasks, which is what a gRPC call is underneath.
+func grpcRequest(ctx context.Context) *http.Request {
+	method, _ := grpc.Method(ctx)
+	return &http.Request{Method: http.MethodPost, URL: &url.URL{Path: method}, Proto: "HTTP/2.0"}
+}
+
+// grpcError converts an error into a status the way the REST handlers pick
+// response codes. Anything unexpected is reported and hidden from the client.
+func (app *application) grpcError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, downloader.ErrUnsupported):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, downloader.ErrPasswordProtected), errors.Is(err, downloader.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, downloader.ErrFileDeleted):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	app.reportServerError(grpcRequest(ctx), err)
+	return status.Error(codes.Internal, "The server encountered a problem and could not process your request")
+}
+
+type animeService struct {
+	restapiv1.UnimplementedAnimeServiceServer
+	app *application
+}
+
+func (s *animeService) Search(ctx context.Context, req *restapiv1.AnimeSearchRequest) (*restapiv1.AnimeSearchResponse, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query can't be empty")
+	}
+
+	page := int(req.GetPage())
+	if page == 0 {
+		page = 1
+	}
+	if page < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page must be greater than 0")
+	}
+
+	result, err := anime.Search(ctx, query, page)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.backgroundTask(grpcRequest(ctx), func() error {
+		return s.app.catalogAnimeResults(result.Results)
+	})
+
+	resp := &restapiv1.AnimeSearchResponse{
+		CurrentPage: int32(result.CurrentPage),
+		HasNextPage: result.HasNextPage,
+	}
+
+	for _, r := range result.Results {
+		resp.Results = append(resp.Results, &restapiv1.AnimeResult{
+			Id:          r.ID,
+			Title:       r.Title,
+			Url:         r.URL,
+			Image:       r.Image,
+			ReleaseDate: r.ReleaseDate,
+			SubOrDub:    string(r.SubOrDub),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *animeService) Info(ctx context.Context, req *restapiv1.AnimeInfoRequest) (*restapiv1.AnimeInfo, error) {
+	id := strings.TrimSpace(req.GetId())
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id can't be empty")
+	}
+
+	info, err := anime.Info(id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.enrichAndCatalogAnimeInfo(grpcRequest(ctx), info, req.GetEnriched())
+
+	return animeInfoProto(info), nil
+}
+
+func (s *animeService) Servers(ctx context.Context, req *restapiv1.EpisodeServersRequest) (*restapiv1.EpisodeServersResponse, error) {
+	id := strings.TrimSpace(req.GetEpisodeId())
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "episode_id can't be empty")
+	}
+
+	servers, err := anime.Downloads(id)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	resp := &restapiv1.EpisodeServersResponse{}
+	for _, server := range servers {
+		resp.Servers = append(resp.Servers, &restapiv1.EpisodeServer{Name: server.Name, Url: server.URL})
+	}
+
+	return resp, nil
+}
+
+type mangaService struct {
+	restapiv1.UnimplementedMangaServiceServer
+	app *application
+}
+
+func (s *mangaService) Search(ctx context.Context, req *restapiv1.MangaSearchRequest) (*restapiv1.MangaSearchResponse, error) {
+	opts := manga.SearchOptions{
+		Title: strings.TrimSpace(req.GetQuery()),
+		Page:  int(req.GetPage()),
+		Limit: int(req.GetLimit()),
+		Lang:  strings.ToLower(strings.TrimSpace(req.GetLang())),
+	}
+
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+
+	switch {
+	case opts.Title == "":
+		return nil, status.Error(codes.InvalidArgument, "query can't be empty")
+	case opts.Page < 0:
+		return nil, status.Error(codes.InvalidArgument, "page must be greater than 0")
+	case opts.Limit < 1 || opts.Limit > 100:
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 1 and 100")
+	}
+
+	result, err := manga.AdvancedSearch(ctx, opts)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	s.app.backgroundTask(grpcRequest(ctx), func() error {
+		return s.app.catalogMangaResults(result.Results)
+	})
+
+	resp := &restapiv1.MangaSearchResponse{
+		CurrentPage: int32(result.CurrentPage),
+		HasNextPage: result.HasNextPage,
+		Total:       int32(result.Total),
+	}
+
+	for _, info := range result.Results {
+		resp.Results = append(resp.Results, mangaProto(info))
+	}
+
+	return resp, nil
+}
+
+type downloaderService struct {
+	restapiv1.UnimplementedDownloaderServiceServer
+	app *application
+}
+
+func (s *downloaderService) Resolve(ctx context.Context, req *restapiv1.ResolveRequest) (*restapiv1.DownloadResult, error) {
+	rawURL := strings.TrimSpace(req.GetUrl())
+	if rawURL == "" {
+		return nil, status.Error(codes.InvalidArgument, "url can't be empty")
+	}
+
+	result, err := downloader.Resolve(ctx, rawURL)
+	if err != nil {
+		return nil, s.app.grpcError(ctx, err)
+	}
+
+	return downloadResultProto(result), nil
+}
+
+func animeInfoProto(info *animeModels.AnimeInfo) *restapiv1.AnimeInfo {
+	resp := &restapiv1.AnimeInfo{
+		Id:            info.ID,
+		Title:         info.Title,
+		Url:           info.URL,
+		Image:         info.Image,
+		ReleaseDate:   info.ReleaseDate,
+		Description:   info.Description,
+		SubOrDub:      string(info.SubOrDub),
+		Type:          string(info.Type),
+		Status:        string(info.Status),
+		OtherName:     info.OtherName,
+		Genres:        info.Genres,
+		TotalEpisodes: int32(info.TotalEpisodes),
+	}
+
+	for _, episode := range info.Episodes {
+		resp.Episodes = append(resp.Episodes, &restapiv1.Episode{Id: episode.ID, Number: episode.Number, Url: episode.URL})
+	}
+
+	if e := info.Enrichment; e != nil {
+		resp.Enrichment = &restapiv1.Enrichment{
+			AnilistId:    int32(e.AniListID),
+			MalId:        int32(e.MalID),
+			Format:       e.Format,
+			Status:       e.Status,
+			Season:       e.Season,
+			SeasonYear:   int32(e.SeasonYear),
+			Episodes:     int32(e.Episodes),
+			Duration:     int32(e.Duration),
+			AverageScore: int32(e.AverageScore),
+			CoverImage:   e.CoverImage,
+			BannerImage:  e.BannerImage,
+		}
+	}
+
+	return resp
+}
+
+func mangaProto(info mangaModels.MangaInfo) *restapiv1.Manga {
+	resp := &restapiv1.Manga{
+		Id:            info.ID,
+		Title:         info.Title,
+		Description:   info.Description,
+		Status:        info.Status,
+		ReleaseDate:   int32(info.ReleaseDate),
+		ContentRating: info.ContentRating,
+		LastVolume:    info.LastVolume,
+		LastChapter:   info.LastChapter,
+		Image:         info.Image,
+	}
+
+	for _, c := range info.Authors {
+		resp.Authors = append(resp.Authors, &restapiv1.Creator{Id: c.ID, Name: c.Name})
+	}
+	for _, c := range info.Artists {
+		resp.Artists = append(resp.Artists, &restapiv1.Creator{Id: c.ID, Name: c.Name})
+	}
+
+	return resp
+}
+
+func downloadResultProto(result *downloaderModels.Result) *restapiv1.DownloadResult {
+	resp := &restapiv1.DownloadResult{
+		Source:    result.Source,
+		Title:     result.Title,
+		Author:    result.Author,
+		Thumbnail: result.Thumbnail,
+		Token:     result.Token,
+	}
+
+	for _, f := range result.Files {
+		resp.Files = append(resp.Files, &restapiv1.DownloadFile{
+			Url:      f.URL,
+			Filename: f.Filename,
+			MimeType: f.MimeType,
+			Size:     f.Size,
+			Quality:  f.Quality,
+		})
+	}
+
+	return resp
+}
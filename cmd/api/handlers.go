@@ -1,76 +1,425 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"miruchigawa.moe/restapi/internal/database"
 	"miruchigawa.moe/restapi/internal/funcs/anime"
-	"miruchigawa.moe/restapi/internal/funcs/manga"
 	"miruchigawa.moe/restapi/internal/funcs/downloader"
+	"miruchigawa.moe/restapi/internal/funcs/manga"
+	"miruchigawa.moe/restapi/internal/funcs/mapping"
+	"miruchigawa.moe/restapi/internal/funcs/search"
+	"miruchigawa.moe/restapi/internal/imageproxy"
+	"miruchigawa.moe/restapi/internal/jobs"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+	downloaderModels "miruchigawa.moe/restapi/internal/models/downloader"
+	searchModels "miruchigawa.moe/restapi/internal/models/search"
+	"miruchigawa.moe/restapi/internal/request"
 	"miruchigawa.moe/restapi/internal/response"
 	"miruchigawa.moe/restapi/internal/validator"
 	"miruchigawa.moe/restapi/internal/version"
+
+	"github.com/gorilla/mux"
 )
 
 func (app *application) status(w http.ResponseWriter, r *http.Request) {
-	data := map[string]any{
-		"Status": "OK",
-		"Message": map[string]any{
-			"Version": version.Get(),
-		},
+	data := struct {
+		Version string `json:"version"`
+	}{
+		Version: version.Get(),
 	}
 
-	err := response.JSON(w, http.StatusOK, data)
+	err := response.Data(w, r, http.StatusOK, data)
 	if err != nil {
 		app.serverError(w, r, err)
 	}
 }
 
 func (app *application) animeSearch(w http.ResponseWriter, r *http.Request) {
-	var name string
-	var page int
 	query := r.URL.Query()
 	v := validator.Validator{}
 
-	if queryName := query.Get("query"); queryName != "" {
-		name = strings.TrimSpace(queryName)
-		v.Check(len(name) > 0, "query can't be empty!")
-	} else {
-		v.AddError("query can't be empty!")
+	filter := anime.SearchFilter{
+		Keyword:  strings.TrimSpace(query.Get("query")),
+		Genres:   app.readCSV(query, "genre"),
+		Year:     strings.TrimSpace(query.Get("year")),
+		Season:   strings.ToLower(strings.TrimSpace(query.Get("season"))),
+		Status:   strings.ToLower(strings.TrimSpace(query.Get("status"))),
+		Type:     strings.ToLower(strings.TrimSpace(query.Get("type"))),
+		Language: strings.ToLower(strings.TrimSpace(query.Get("language"))),
+		Sort:     strings.ToLower(strings.TrimSpace(query.Get("sort"))),
+	}
+
+	page := app.readInt(query, "page", 1, &v)
+	v.Check(page > 0, "page must be greater than 0!")
+
+	local := app.readBool(query, "local", false, &v)
+	proxyImages := app.readBool(query, "proxyImages", false, &v)
+
+	hasFilter := len(filter.Genres) > 0 || filter.Year != "" || filter.Season != "" || filter.Status != "" || filter.Type != "" || filter.Language != ""
+	v.Check(filter.Keyword != "" || hasFilter, "query can't be empty!")
+
+	v.CheckField(validator.AllIn(filter.Genres, anime.GenreIDs()...), "genre", "genre contains an unknown genre")
+	v.CheckField(validator.NoDuplicates(filter.Genres), "genre", "genre must not contain duplicate values")
+
+	if filter.Year != "" {
+		year, err := strconv.Atoi(filter.Year)
+		v.CheckField(err == nil && validator.Between(year, 1917, time.Now().Year()+1), "year", "year must be a valid release year")
+	}
+
+	if filter.Season != "" {
+		v.CheckField(validator.In(filter.Season, anime.Seasons...), "season", "season must be one of "+strings.Join(anime.Seasons, ", "))
+	}
+	if filter.Status != "" {
+		v.CheckField(validator.In(filter.Status, anime.Statuses...), "status", "status must be one of "+strings.Join(anime.Statuses, ", "))
+	}
+	if filter.Type != "" {
+		v.CheckField(validator.In(filter.Type, anime.Types...), "type", "type must be one of "+strings.Join(anime.Types, ", "))
+	}
+	if filter.Language != "" {
+		v.CheckField(validator.In(filter.Language, anime.Languages...), "language", "language must be one of "+strings.Join(anime.Languages, ", "))
+	}
+	if filter.Sort != "" {
+		v.CheckField(validator.In(filter.Sort, anime.Sorts...), "sort", "sort must be one of "+strings.Join(anime.Sorts, ", "))
+	}
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	var result *animeModels.SearchResult
+
+	if local && !hasFilter && filter.Sort == "" {
+		localResult, err := app.localAnimeSearch(filter.Keyword, page)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		if len(localResult.Results) > 0 {
+			result = localResult
+		}
+	}
+
+	if result == nil {
+		upstreamResult, err := anime.AdvancedSearch(r.Context(), filter, page)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		app.backgroundTask(r, func() error {
+			return app.catalogAnimeResults(upstreamResult.Results)
+		})
+
+		result = upstreamResult
+	}
+
+	if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) animeGenres(w http.ResponseWriter, r *http.Request) {
+	if err := response.Data(w, r, http.StatusOK, anime.ListGenres()); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) animeRecent(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	page := app.readInt(query, "page", 1, &v)
+	v.Check(page > 0, "page must be greater than 0!")
+
+	releaseType := animeModels.RELEASE_SUB
+	switch query.Get("type") {
+	case "", "sub":
+	case "dub":
+		releaseType = animeModels.RELEASE_DUB
+	case "chinese":
+		releaseType = animeModels.RELEASE_CHINESE
+	default:
+		v.AddError("type must be one of sub, dub or chinese!")
+	}
+
+	proxyImages := app.readBool(query, "proxyImages", false, &v)
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	result, err := anime.RecentReleases(page, releaseType)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
+		app.serverError(w, r, err)
 	}
+}
+
+func (app *application) animeSchedule(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
 
-	if pageQuery := query.Get("page"); pageQuery != "" {
-		if num, err := strconv.Atoi(pageQuery); err == nil || num < 1 {
-			page = num
+	loc := time.UTC
+	if tz := strings.TrimSpace(query.Get("tz")); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			v.AddFieldError("tz", "tz must be a valid IANA time zone name")
 		} else {
-			v.AddError("Invalid page number format!")
+			loc = l
 		}
-	} else {
-		page = 1
 	}
 
+	day := strings.ToLower(strings.TrimSpace(query.Get("day")))
+	if day != "" {
+		v.CheckField(validator.In(day, "today", "sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"), "day", "day must be today or a day of the week")
+	}
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	now := time.Now()
+
+	releases, err := app.db.GetEpisodeReleasesSince(now.Add(-anime.ScheduleHistory))
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	history := make([]animeModels.ReleaseRecord, 0, len(releases))
+	for _, release := range releases {
+		history = append(history, animeModels.ReleaseRecord{
+			AnimeID:       release.AnimeID,
+			Title:         release.Title,
+			Image:         release.Image,
+			EpisodeNumber: release.EpisodeNumber,
+			SubOrDub:      animeModels.SubOrDub(release.SubOrDub),
+			ReleasedAt:    release.ReleasedAt,
+		})
+	}
+
+	result := anime.Schedule(history, loc, now)
+
+	switch day {
+	case "":
+	case "today":
+		result.Days = result.Days[:1]
+	default:
+		for _, scheduleDay := range result.Days {
+			if strings.EqualFold(scheduleDay.Day, day) {
+				result.Days = []animeModels.ScheduleDay{scheduleDay}
+				break
+			}
+		}
+	}
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) animeMapping(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	for _, external := range mapping.Externals {
+		if query.Get(external) == "" {
+			continue
+		}
+
+		id := app.readInt(query, external, 0, &v)
+		v.CheckField(id > 0, external, external+" must be a positive integer")
+
+		if v.HasErrors() {
+			app.failedValidation(w, r, v)
+			return
+		}
+
+		mappings, err := app.db.GetAnimeMappingsByExternal(external, id)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		result := make([]*animeModels.Mapping, 0, len(mappings))
+		var modified time.Time
+		for _, m := range mappings {
+			result = append(result, mappingFromDatabase(m))
+			if m.UpdatedAt.After(modified) {
+				modified = m.UpdatedAt
+			}
+		}
+
+		app.setCacheHeaders(w, modified, mappingMaxAge)
+
+		if err := response.Data(w, r, http.StatusOK, result); err != nil {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	provider := strings.TrimSpace(query.Get("provider"))
+	if provider == "" {
+		provider = mapping.ProviderAnitaku
+	}
+	v.CheckField(validator.In(provider, mapping.Providers...), "provider", "provider must be one of "+strings.Join(mapping.Providers, ", "))
+
+	id := strings.TrimSpace(query.Get("id"))
+	v.Check(id != "", "id can't be empty!")
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	result, err := app.resolveAnimeMapping(provider, id, nil)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	if result.Confirmed {
+		app.setCacheHeaders(w, result.UpdatedAt, mappingMaxAge)
+	}
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) adminPutAnimeMapping(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Provider  string              `json:"Provider"`
+		ID        string              `json:"ID"`
+		AniListID int                 `json:"AniListID"`
+		MalID     int                 `json:"MalID"`
+		KitsuID   int                 `json:"KitsuID"`
+		Validator validator.Validator `json:"-"`
+	}
+
+	err := request.DecodeJSONStrict(w, r, &input)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	input.Validator.CheckField(validator.In(input.Provider, mapping.Providers...), "Provider", "Provider must be one of "+strings.Join(mapping.Providers, ", "))
+	input.Validator.CheckField(validator.NotBlank(input.ID), "ID", "ID is required")
+	input.Validator.CheckField(input.AniListID >= 0, "AniListID", "AniListID must not be negative")
+	input.Validator.CheckField(input.MalID >= 0, "MalID", "MalID must not be negative")
+	input.Validator.CheckField(input.KitsuID >= 0, "KitsuID", "KitsuID must not be negative")
+	input.Validator.Check(input.AniListID != 0 || input.MalID != 0 || input.KitsuID != 0, "At least one external ID is required")
+
+	if input.Validator.HasErrors() {
+		app.failedValidation(w, r, input.Validator)
+		return
+	}
+
+	err = app.db.UpsertAnimeMapping(database.AnimeMapping{
+		Provider:   input.Provider,
+		ProviderID: strings.TrimSpace(input.ID),
+		AniListID:  input.AniListID,
+		MalID:      input.MalID,
+		KitsuID:    input.KitsuID,
+		Confidence: 1,
+		Manual:     true,
+	})
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	stored, _, err := app.db.GetAnimeMapping(input.Provider, strings.TrimSpace(input.ID))
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, mappingFromDatabase(stored)); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) adminDeleteAnimeMapping(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	provider := strings.TrimSpace(query.Get("provider"))
+	v.CheckField(validator.In(provider, mapping.Providers...), "provider", "provider must be one of "+strings.Join(mapping.Providers, ", "))
+
+	id := strings.TrimSpace(query.Get("id"))
+	v.Check(id != "", "id can't be empty!")
+
 	if v.HasErrors() {
 		app.failedValidation(w, r, v)
 		return
 	}
 
-	result, err := anime.Search(name, page)
+	deleted, err := app.db.DeleteAnimeMapping(provider, id)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
+	if !deleted {
+		app.notFound(w, r)
+		return
 	}
 
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	if err := response.Data(w, r, http.StatusOK, "Mapping deleted"); err != nil {
 		app.serverError(w, r, err)
 	}
 }
 
+func (app *application) animeListing(fetch func(page int) (*animeModels.SearchResult, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		v := validator.Validator{}
+
+		page := app.readInt(query, "page", 1, &v)
+		v.Check(page > 0, "page must be greater than 0!")
+
+		proxyImages := app.readBool(query, "proxyImages", false, &v)
+
+		if v.HasErrors() {
+			app.failedValidation(w, r, v)
+			return
+		}
+
+		result, err := fetch(page)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		app.backgroundTask(r, func() error {
+			return app.catalogAnimeResults(result.Results)
+		})
+
+		if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
+			app.serverError(w, r, err)
+		}
+	}
+}
+
 func (app *application) animeInfo(w http.ResponseWriter, r *http.Request) {
 	var id string
 	query := r.URL.Query()
@@ -83,23 +432,28 @@ func (app *application) animeInfo(w http.ResponseWriter, r *http.Request) {
 		v.AddError("id can't be empty!")
 	}
 
+	enriched := app.readBool(query, "enriched", false, &v)
+	proxyImages := app.readBool(query, "proxyImages", false, &v)
+
 	if v.HasErrors() {
 		app.failedValidation(w, r, v)
 		return
 	}
 
+	if response.WantsEventStream(r) {
+		app.animeInfoStream(w, r, id, enriched, proxyImages)
+		return
+	}
+
 	result, err := anime.Info(id)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
-	}
+	app.enrichAndCatalogAnimeInfo(r, result, enriched)
 
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
 		app.serverError(w, r, err)
 	}
 }
@@ -127,20 +481,296 @@ func (app *application) animeDownload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) mangaSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	opts := manga.SearchOptions{
+		Title:                       strings.TrimSpace(query.Get("query")),
+		IncludedTags:                app.readCSV(query, "includedTags"),
+		ExcludedTags:                app.readCSV(query, "excludedTags"),
+		Status:                      app.readCSV(query, "status"),
+		PublicationDemographic:      app.readCSV(query, "publicationDemographic"),
+		ContentRating:               app.readCSV(query, "contentRating"),
+		OriginalLanguage:            app.readCSV(query, "originalLanguage"),
+		AvailableTranslatedLanguage: app.readCSV(query, "availableTranslatedLanguage"),
+		OrderField:                  strings.TrimSpace(query.Get("order")),
+		OrderDirection:              strings.ToLower(strings.TrimSpace(query.Get("direction"))),
+		Lang:                        strings.ToLower(strings.TrimSpace(query.Get("lang"))),
+	}
+
+	opts.Page = app.readInt(query, "page", 1, &v)
+	v.Check(opts.Page > 0, "page must be greater than 0!")
+
+	opts.Limit = app.readInt(query, "limit", 20, &v)
+	v.CheckField(validator.Between(opts.Limit, 1, 100), "limit", "limit must be between 1 and 100")
+
+	opts.Year = app.readInt(query, "year", 0, &v)
+	v.CheckField(opts.Year == 0 || validator.Between(opts.Year, 1900, time.Now().Year()+1), "year", "year must be a valid publication year")
+
+	hasFilter := len(opts.IncludedTags) > 0 || len(opts.ExcludedTags) > 0 || len(opts.Status) > 0 ||
+		len(opts.PublicationDemographic) > 0 || len(opts.ContentRating) > 0 || len(opts.OriginalLanguage) > 0 ||
+		len(opts.AvailableTranslatedLanguage) > 0 || opts.Year > 0
+	v.Check(opts.Title != "" || hasFilter, "query can't be empty!")
+
+	for key, tags := range map[string][]string{"includedTags": opts.IncludedTags, "excludedTags": opts.ExcludedTags} {
+		for _, tag := range tags {
+			v.CheckField(validator.Matches(tag, validator.RgxUUID), key, key+" must be MangaDex tag IDs")
+		}
+	}
+	v.CheckField(validator.AllIn(opts.Status, manga.Statuses...), "status", "status must be one of "+strings.Join(manga.Statuses, ", "))
+	v.CheckField(validator.AllIn(opts.PublicationDemographic, manga.Demographics...), "publicationDemographic", "publicationDemographic must be one of "+strings.Join(manga.Demographics, ", "))
+	v.CheckField(validator.AllIn(opts.ContentRating, manga.ContentRatings...), "contentRating", "contentRating must be one of "+strings.Join(manga.ContentRatings, ", "))
+	for key, langs := range map[string][]string{"originalLanguage": opts.OriginalLanguage, "availableTranslatedLanguage": opts.AvailableTranslatedLanguage} {
+		for _, lang := range langs {
+			v.CheckField(validator.Matches(lang, validator.RgxLanguageCode), key, key+" must be ISO 639-1 language codes")
+		}
+	}
+	if opts.OrderField != "" {
+		v.CheckField(validator.In(opts.OrderField, manga.OrderFields...), "order", "order must be one of "+strings.Join(manga.OrderFields, ", "))
+	}
+	if opts.OrderDirection != "" {
+		v.CheckField(validator.In(opts.OrderDirection, manga.OrderDirections...), "direction", "direction must be asc or desc")
+	}
+	if opts.Lang != "" {
+		v.CheckField(validator.Matches(opts.Lang, validator.RgxLanguageCode), "lang", "lang must be an ISO 639-1 language code")
+	}
+
+	local := app.readBool(query, "local", false, &v)
+	proxyImages := app.readBool(query, "proxyImages", false, &v)
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	if local && !hasFilter && opts.OrderField == "" {
+		result, err := app.localMangaSearch(opts.Title, opts.Page, opts.Limit)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		if len(result.Results) > 0 {
+			if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
+				app.serverError(w, r, err)
+			}
+			return
+		}
 	}
 
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	result, err := manga.AdvancedSearch(r.Context(), opts)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.backgroundTask(r, func() error {
+		return app.catalogMangaResults(result.Results)
+	})
+
+	if err := response.Data(w, r, http.StatusOK, app.proxiedImages(result, proxyImages)); err != nil {
 		app.serverError(w, r, err)
 	}
 }
 
-func (app *application) mangaSearch(w http.ResponseWriter, r *http.Request) {
+func (app *application) mangaTags(w http.ResponseWriter, r *http.Request) {
+	v := validator.Validator{}
+
+	lang := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("lang")))
+	if lang != "" {
+		v.CheckField(validator.Matches(lang, validator.RgxLanguageCode), "lang", "lang must be an ISO 639-1 language code")
+	} else {
+		lang = "en"
+	}
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	result, err := manga.Tags(lang)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) mangaChapterDownload(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	ids := app.readCSV(query, "id")
+	mangaID := strings.ToLower(strings.TrimSpace(query.Get("manga")))
+	volume := strings.TrimSpace(query.Get("volume"))
+
+	lang := strings.ToLower(strings.TrimSpace(query.Get("lang")))
+	if lang == "" {
+		lang = "en"
+	}
+
+	// The archive format can be given as format, or as archive to leave
+	// format free to pick the format of any JSON response.
+	formatName := strings.ToLower(strings.TrimSpace(query.Get("archive")))
+	if formatName == "" {
+		formatName = "cbz"
+		if name := strings.ToLower(strings.TrimSpace(query.Get("format"))); name != "" {
+			if _, ok := chapterFormats[name]; ok {
+				formatName = name
+			}
+		}
+	}
+
+	dataSaver := app.readBool(query, "dataSaver", false, &v)
+	async := app.readBool(query, "async", false, &v)
+
+	if len(ids) > 0 {
+		v.Check(mangaID == "" && volume == "", "id can't be combined with manga and volume!")
+		v.CheckField(len(ids) <= 50, "id", "id must not contain more than 50 chapters")
+		v.CheckField(validator.NoDuplicates(ids), "id", "id must not contain duplicate values")
+		for _, id := range ids {
+			v.CheckField(validator.Matches(id, validator.RgxUUID), "id", "id must be a MangaDex chapter UUID")
+		}
+	} else {
+		v.Check(mangaID != "" && volume != "", "id or manga and volume can't be empty!")
+		if mangaID != "" {
+			v.CheckField(validator.Matches(mangaID, validator.RgxUUID), "manga", "manga must be a MangaDex manga UUID")
+		}
+	}
+
+	v.CheckField(validator.Matches(lang, validator.RgxLanguageCode), "lang", "lang must be an ISO 639-1 language code")
+
+	format, ok := chapterFormats[formatName]
+	v.CheckField(ok, "archive", "archive must be one of cbz, epub")
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	if len(ids) == 0 {
+		var err error
+
+		ids, err = manga.VolumeChapters(r.Context(), mangaID, volume, lang)
+		if errors.Is(err, manga.ErrVolumeNotFound) {
+			app.errorMessage(w, r, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+	}
+
+	// Books are built in memory before any of them is written, so anything
+	// longer than a chapter always goes through the job queue.
+	if async || len(ids) > 1 {
+		job, err := app.jobs.Enqueue("manga-chapter-download", func(ctx context.Context, h *jobs.Handle) error {
+			book, err := app.chapterBook(ctx, ids, volume, dataSaver, h.SetProgress)
+			if err != nil {
+				return err
+			}
+
+			f, err := h.CreateFile(chapterFileName(book, format))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			return format.write(f, book)
+		})
+		if errors.Is(err, jobs.ErrQueueFull) {
+			app.errorMessage(w, r, http.StatusServiceUnavailable, err.Error(), nil)
+			return
+		}
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		headers := make(http.Header)
+		headers.Set("Location", app.versionedPath(r, "/jobs/"+job.ID))
+
+		if err := response.DataWithHeaders(w, r, http.StatusAccepted, job, headers); err != nil {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	// Fetching a long chapter can take far longer than the server's default
+	// write timeout.
+	err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(chapterDownloadTimeout))
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	book, err := app.chapterBook(r.Context(), ids, volume, dataSaver, nil)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": chapterFileName(book, format)}))
+	w.WriteHeader(http.StatusOK)
+
+	if err := format.write(w, book); err != nil {
+		app.reportServerError(r, err)
+	}
+}
+
+func (app *application) jobStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := app.jobs.Get(mux.Vars(r)["id"])
+	if !ok {
+		app.notFound(w, r)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, job); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) jobDownload(w http.ResponseWriter, r *http.Request) {
+	f, job, err := app.jobs.Open(mux.Vars(r)["id"])
+	if errors.Is(err, os.ErrNotExist) {
+		app.notFound(w, r)
+		return
+	}
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	defer f.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(job.FileName))
+	for _, format := range chapterFormats {
+		if format.extension == filepath.Ext(job.FileName) {
+			contentType = format.contentType
+		}
+	}
+
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": job.FileName}))
+
+	http.ServeContent(w, r, job.FileName, job.UpdatedAt, f)
+}
+
+func (app *application) unifiedSearch(w http.ResponseWriter, r *http.Request) {
 	var name string
-	var page int
-	var limit int
+	var weights map[string]float64
 	query := r.URL.Query()
 	v := validator.Validator{}
 
@@ -151,48 +781,152 @@ func (app *application) mangaSearch(w http.ResponseWriter, r *http.Request) {
 		v.AddError("query can't be empty!")
 	}
 
-	if pageQuery := query.Get("page"); pageQuery != "" {
-		if num, err := strconv.Atoi(pageQuery); err == nil || num < 1 {
-			page = num
-		} else {
-			v.AddError("Invalid page number format!")
+	if weightQuery := query.Get("weight"); weightQuery != "" {
+		parsed, err := search.ParseWeights(weightQuery)
+		if err != nil {
+			v.AddFieldError("weight", "weight must be in the form source:weight,source:weight")
 		}
-	} else {
-		page = 1
+		weights = parsed
 	}
 
-	if limitQuery := query.Get("page"); limitQuery != "" {
-		if num, err := strconv.Atoi(limitQuery); err == nil || num < 1 {
-			limit = num
-		} else {
-			v.AddError("Invalid limit number format!")
-		}
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	if response.WantsEventStream(r) {
+		app.unifiedSearchStream(w, r, name, weights)
+		return
+	}
+
+	result := search.Search(r.Context(), name, weights)
+
+	app.backgroundTask(r, func() error {
+		return app.catalogSearchResults(result.Results)
+	})
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
+		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) catalogSearch(w http.ResponseWriter, r *http.Request) {
+	var name string
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	if queryName := query.Get("query"); queryName != "" {
+		name = strings.TrimSpace(queryName)
+		v.Check(len(name) > 0, "query can't be empty!")
 	} else {
-		limit = 20
+		v.AddError("query can't be empty!")
 	}
 
+	kind := strings.ToUpper(strings.TrimSpace(query.Get("kind")))
+	if kind != "" {
+		v.CheckField(validator.In(kind, string(searchModels.ANIME), string(searchModels.MANGA)), "kind", "kind must be anime or manga")
+	}
+
+	page := app.readInt(query, "page", 1, &v)
+	v.Check(page > 0, "page must be greater than 0!")
+
+	limit := app.readInt(query, "limit", 20, &v)
+	v.CheckField(validator.Between(limit, 1, 100), "limit", "limit must be between 1 and 100")
+
 	if v.HasErrors() {
 		app.failedValidation(w, r, v)
 		return
 	}
 
-	result, err := manga.Search(name, page, limit)
+	entries, err := app.db.SearchCatalog(kind, name, limit+1, limit*(page-1))
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
+	result := &searchModels.CatalogResults{
+		CurrentPage: page,
+		HasNextPage: len(entries) > limit,
+		Results:     []searchModels.Result{},
+	}
+
+	var modified time.Time
+	for i, entry := range entries {
+		if i == limit {
+			break
+		}
+		result.Results = append(result.Results, catalogResult(entry))
+		if entry.UpdatedAt.After(modified) {
+			modified = entry.UpdatedAt
+		}
 	}
 
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	app.setCacheHeaders(w, modified, catalogMaxAge)
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
 		app.serverError(w, r, err)
 	}
 }
 
-func (app *application) mediafire(w http.ResponseWriter, r *http.Request) {
+func (app *application) imageProxy(w http.ResponseWriter, r *http.Request) {
+	var imageURL string
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	if queryUrl := query.Get("url"); queryUrl != "" {
+		imageURL = strings.TrimSpace(queryUrl)
+		v.CheckField(validator.IsURL(imageURL), "url", "url must be a valid URL")
+		v.CheckField(app.images.Allowed(imageURL), "url", "url host is not allowed")
+	} else {
+		v.AddError("url can't be empty!")
+	}
+
+	opts := imageproxy.Options{
+		Width:  app.readInt(query, "w", 0, &v),
+		Height: app.readInt(query, "h", 0, &v),
+		Format: strings.ToLower(strings.TrimSpace(query.Get("fmt"))),
+	}
+
+	v.CheckField(validator.Between(opts.Width, 0, imageproxy.MaxDimension), "w", fmt.Sprintf("w must be between 0 and %d", imageproxy.MaxDimension))
+	v.CheckField(validator.Between(opts.Height, 0, imageproxy.MaxDimension), "h", fmt.Sprintf("h must be between 0 and %d", imageproxy.MaxDimension))
+
+	if opts.Format == "jpg" {
+		opts.Format = "jpeg"
+	}
+	if opts.Format != "" {
+		v.CheckField(validator.In(opts.Format, imageproxy.Formats...), "fmt", "fmt must be one of "+strings.Join(imageproxy.Formats, ", "))
+	}
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	img, err := app.images.Get(r.Context(), imageURL, opts)
+	switch {
+	case errors.Is(err, imageproxy.ErrNotImage), errors.Is(err, imageproxy.ErrTooLarge), errors.Is(err, imageproxy.ErrHostNotAllowed):
+		app.errorMessage(w, r, http.StatusBadGateway, err.Error(), nil)
+		return
+	case err != nil:
+		app.serverError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", img.ETag)
+
+	if match := r.Header.Get("If-None-Match"); match != "" && strings.Contains(match, img.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(img.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(img.Data)
+}
+
+func (app *application) downloader(w http.ResponseWriter, r *http.Request) {
 	var url string
 	query := r.URL.Query()
 	v := validator.Validator{}
@@ -200,6 +934,7 @@ func (app *application) mediafire(w http.ResponseWriter, r *http.Request) {
 	if queryUrl := query.Get("url"); queryUrl != "" {
 		url = strings.TrimSpace(queryUrl)
 		v.Check(len(url) > 0, "url can't be empty!")
+		v.CheckField(validator.IsURL(url), "url", "url must be a valid URL")
 	} else {
 		v.AddError("url can't be empty!")
 	}
@@ -209,18 +944,57 @@ func (app *application) mediafire(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := downloader.GetMediafireInfo(url)
+	d, ok := downloader.Find(url)
+	if !ok {
+		v.AddFieldError("url", "url is not on a supported host")
+		app.failedValidation(w, r, v)
+		return
+	}
+
+	result, err := d.Resolve(r.Context(), url)
 	if err != nil {
+		app.downloaderError(w, r, err)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
 		app.serverError(w, r, err)
+	}
+}
+
+func (app *application) mediafire(w http.ResponseWriter, r *http.Request) {
+	var url string
+	query := r.URL.Query()
+	v := validator.Validator{}
+
+	if queryUrl := query.Get("url"); queryUrl != "" {
+		url = strings.TrimSpace(queryUrl)
+		v.Check(len(url) > 0, "url can't be empty!")
+		v.CheckField(validator.IsURL(url) && downloader.IsMediafireURL(url), "url", "url must be a MediaFire link")
+	} else {
+		v.AddError("url can't be empty!")
+	}
+
+	if v.HasErrors() {
+		app.failedValidation(w, r, v)
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
+	var result any
+	var err error
+
+	if downloader.MediafireFolderKey(url) != "" {
+		result, err = downloader.GetMediafireFolder(r.Context(), url)
+	} else {
+		result, err = downloader.GetMediafireInfo(r.Context(), url)
 	}
 
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	if err != nil {
+		app.downloaderError(w, r, err)
+		return
+	}
+
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
 		app.serverError(w, r, err)
 	}
 
@@ -234,6 +1008,7 @@ func (app *application) tiktokDownloader(w http.ResponseWriter, r *http.Request)
 	if queryUrl := query.Get("url"); queryUrl != "" {
 		url = strings.TrimSpace(queryUrl)
 		v.Check(len(url) > 0, "url can't be empty!")
+		v.CheckField(validator.IsURL(url) && downloader.Tiktok{}.Match(url), "url", "url must be a TikTok link")
 	} else {
 		v.AddError("url can't be empty!")
 	}
@@ -243,19 +1018,45 @@ func (app *application) tiktokDownloader(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := downloader.TiktokDownloader(url)
+	result, err := downloader.TiktokDownloader(r.Context(), url)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
 
-	data := map[string]any{
-		"Status":  "OK",
-		"Message": result,
-	}
-
-	if err := response.JSON(w, http.StatusOK, data); err != nil {
+	if err := response.Data(w, r, http.StatusOK, result); err != nil {
 		app.serverError(w, r, err)
 	}
 
 }
+
+func (app *application) socialDownloader(match func(url string) bool, fetch func(ctx context.Context, url string) (*downloaderModels.SocialPost, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var url string
+		query := r.URL.Query()
+		v := validator.Validator{}
+
+		if queryUrl := query.Get("url"); queryUrl != "" {
+			url = strings.TrimSpace(queryUrl)
+			v.Check(len(url) > 0, "url can't be empty!")
+			v.CheckField(validator.IsURL(url) && match(url), "url", "url is not a supported post link")
+		} else {
+			v.AddError("url can't be empty!")
+		}
+
+		if v.HasErrors() {
+			app.failedValidation(w, r, v)
+			return
+		}
+
+		result, err := fetch(r.Context(), url)
+		if err != nil {
+			app.downloaderError(w, r, err)
+			return
+		}
+
+		if err := response.Data(w, r, http.StatusOK, result); err != nil {
+			app.serverError(w, r, err)
+		}
+	}
+}
@@ -3,6 +3,13 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/response"
+	"miruchigawa.moe/restapi/internal/validator"
 )
 
 func (app *application) newEmailData() map[string]any {
@@ -32,3 +39,68 @@ func (app *application) backgroundTask(r *http.Request, fn func() error) {
 		}
 	}()
 }
+
+func (app *application) readInt(qs url.Values, key string, defaultValue int, v *validator.Validator) int {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		v.AddError(fmt.Sprintf("Invalid %s number format!", key))
+		return defaultValue
+	}
+
+	return i
+}
+
+func (app *application) readCSV(qs url.Values, key string) []string {
+	var values []string
+
+	for _, s := range qs[key] {
+		for _, value := range strings.Split(s, ",") {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func (app *application) readBool(qs url.Values, key string, defaultValue bool, v *validator.Validator) bool {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddFieldError(key, fmt.Sprintf("%s must be true or false", key))
+		return defaultValue
+	}
+
+	return b
+}
+
+// versionedPath prefixes path with the API version of the request, so links
+// handed back to v2 clients stay on v2.
+func (app *application) versionedPath(r *http.Request, path string) string {
+	if response.VersionOf(r) == response.V2 {
+		return "/v2" + path
+	}
+
+	return path
+}
+
+// setCacheHeaders marks a response built from stored data as last modified at
+// modified and lets clients reuse it for maxAge before revalidating.
+func (app *application) setCacheHeaders(w http.ResponseWriter, modified time.Time, maxAge time.Duration) {
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+}
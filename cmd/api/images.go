@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// proxiedImages returns a copy of v with every image URL the proxy is allowed
+// to fetch pointed at /img instead. Fields named Image, ending in Image or
+// named Thumbnails are rewritten. v itself is left alone since it is usually
+// still being read by a background task.
+func (app *application) proxiedImages(v any, enabled bool) any {
+	if !enabled || v == nil {
+		return v
+	}
+
+	return app.rewriteImages(reflect.ValueOf(v), false).Interface()
+}
+
+func (app *application) rewriteImages(v reflect.Value, isImage bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(app.rewriteImages(v.Elem(), isImage))
+		return copied
+
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			copied.Field(i).Set(app.rewriteImages(v.Field(i), strings.HasSuffix(field.Name, "Image") || field.Name == "Thumbnails"))
+		}
+
+		return copied
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(app.rewriteImages(v.Index(i), isImage))
+		}
+		return copied
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), app.rewriteImages(iter.Value(), isImage))
+		}
+		return copied
+
+	case reflect.String:
+		if isImage && app.images.Allowed(v.String()) {
+			return reflect.ValueOf(app.proxyImageURL(v.String())).Convert(v.Type())
+		}
+	}
+
+	return v
+}
+
+func (app *application) proxyImageURL(imageURL string) string {
+	return strings.TrimSuffix(app.config.baseURL, "/") + "/img?url=" + url.QueryEscape(imageURL)
+}
@@ -5,15 +5,27 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"runtime/debug"
+	"strings"
 	"sync"
+	"time"
 
 	"miruchigawa.moe/restapi/internal/database"
 	"miruchigawa.moe/restapi/internal/env"
+	"miruchigawa.moe/restapi/internal/events"
+	"miruchigawa.moe/restapi/internal/funcs/anilist"
+	"miruchigawa.moe/restapi/internal/funcs/downloader"
+	"miruchigawa.moe/restapi/internal/funcs/mapping"
+	"miruchigawa.moe/restapi/internal/imageproxy"
+	"miruchigawa.moe/restapi/internal/jobs"
 	"miruchigawa.moe/restapi/internal/smtp"
 	"miruchigawa.moe/restapi/internal/version"
 
+	"github.com/graphql-go/graphql"
 	"github.com/lmittmann/tint"
+
+	_ "time/tzdata"
 )
 
 func main() {
@@ -28,15 +40,57 @@ func main() {
 }
 
 type config struct {
-	baseURL  string
-	httpPort int
-	db       struct {
+	baseURL     string
+	httpPort    int
+	grpcPort    int
+	development bool
+	db          struct {
 		dsn         string
 		automigrate bool
 	}
 	notifications struct {
 		email string
 	}
+	releases struct {
+		checkInterval time.Duration
+	}
+	admin struct {
+		token string
+	}
+	jobs struct {
+		dir     string
+		workers int
+		ttl     time.Duration
+	}
+	clients struct {
+		tokens []string
+	}
+	batch struct {
+		parallelism int
+	}
+	cors struct {
+		allowedOrigins   []string
+		allowedMethods   []string
+		allowedHeaders   []string
+		exposedHeaders   []string
+		allowCredentials bool
+		maxAge           time.Duration
+	}
+	compression struct {
+		minSize int
+	}
+	images struct {
+		cacheDir  string
+		cacheSize int
+		hosts     []string
+	}
+	upstream struct {
+		anilistURL   string
+		kitsuURL     string
+		instagramURL string
+		fxtwitterURL string
+		youtubeURL   string
+	}
 	smtp struct {
 		host     string
 		port     int
@@ -47,11 +101,15 @@ type config struct {
 }
 
 type application struct {
-	config config
-	db     *database.DB
-	logger *slog.Logger
-	mailer *smtp.Mailer
-	wg     sync.WaitGroup
+	config        config
+	db            *database.DB
+	logger        *slog.Logger
+	mailer        *smtp.Mailer
+	jobs          *jobs.Queue
+	images        *imageproxy.Proxy
+	events        *events.Hub
+	graphqlSchema graphql.Schema
+	wg            sync.WaitGroup
 }
 
 func run(logger *slog.Logger) error {
@@ -59,9 +117,33 @@ func run(logger *slog.Logger) error {
 
 	cfg.baseURL = env.GetString("BASE_URL", "http://localhost:4444")
 	cfg.httpPort = env.GetInt("HTTP_PORT", 4444)
+	cfg.grpcPort = env.GetInt("GRPC_PORT", 0)
+	cfg.development = env.GetBool("DEVELOPMENT", false)
+	cfg.admin.token = env.GetString("ADMIN_TOKEN", "")
+	cfg.upstream.anilistURL = env.GetString("ANILIST_URL", anilist.APIURL)
+	cfg.upstream.kitsuURL = env.GetString("KITSU_URL", mapping.KitsuURL)
+	cfg.upstream.instagramURL = env.GetString("INSTAGRAM_URL", downloader.InstagramURL)
+	cfg.upstream.fxtwitterURL = env.GetString("FXTWITTER_URL", downloader.FxTwitterURL)
+	cfg.upstream.youtubeURL = env.GetString("YOUTUBE_URL", downloader.YoutubeURL)
 	cfg.db.dsn = env.GetString("DB_DSN", "db.sqlite")
 	cfg.db.automigrate = env.GetBool("DB_AUTOMIGRATE", true)
 	cfg.notifications.email = env.GetString("NOTIFICATIONS_EMAIL", "")
+	cfg.releases.checkInterval = env.GetDuration("RELEASES_CHECK_INTERVAL", 15*time.Minute)
+	cfg.jobs.dir = env.GetString("JOBS_DIR", filepath.Join(os.TempDir(), "restapi-jobs"))
+	cfg.jobs.workers = env.GetInt("JOBS_WORKERS", 2)
+	cfg.jobs.ttl = env.GetDuration("JOBS_TTL", time.Hour)
+	cfg.clients.tokens = splitList(env.GetString("CLIENT_TOKENS", ""))
+	cfg.batch.parallelism = env.GetInt("BATCH_PARALLELISM", 4)
+	cfg.cors.allowedOrigins = splitList(env.GetString("CORS_ALLOWED_ORIGINS", ""))
+	cfg.cors.allowedMethods = splitList(env.GetString("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE"))
+	cfg.cors.allowedHeaders = splitList(env.GetString("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,If-None-Match,X-Request-ID"))
+	cfg.cors.exposedHeaders = splitList(env.GetString("CORS_EXPOSED_HEADERS", "ETag,Location,X-Request-ID"))
+	cfg.cors.allowCredentials = env.GetBool("CORS_ALLOW_CREDENTIALS", false)
+	cfg.cors.maxAge = env.GetDuration("CORS_MAX_AGE", 10*time.Minute)
+	cfg.compression.minSize = env.GetInt("COMPRESSION_MIN_SIZE", 1024)
+	cfg.images.cacheDir = env.GetString("IMAGE_CACHE_DIR", filepath.Join(os.TempDir(), "restapi-images"))
+	cfg.images.cacheSize = env.GetInt("IMAGE_CACHE_SIZE", 256<<20)
+	cfg.images.hosts = splitList(env.GetString("IMAGE_PROXY_HOSTS", "gogocdn.net,anitaku.pe,uploads.mangadex.org,mangadex.network,anilist.co,kitsu.io"))
 	cfg.smtp.host = env.GetString("SMTP_HOST", "example.smtp.host")
 	cfg.smtp.port = env.GetInt("SMTP_PORT", 25)
 	cfg.smtp.username = env.GetString("SMTP_USERNAME", "example_username")
@@ -77,6 +159,12 @@ func run(logger *slog.Logger) error {
 		return nil
 	}
 
+	anilist.APIURL = cfg.upstream.anilistURL
+	mapping.KitsuURL = cfg.upstream.kitsuURL
+	downloader.InstagramURL = cfg.upstream.instagramURL
+	downloader.FxTwitterURL = cfg.upstream.fxtwitterURL
+	downloader.YoutubeURL = cfg.upstream.youtubeURL
+
 	db, err := database.New(cfg.db.dsn, cfg.db.automigrate)
 	if err != nil {
 		return err
@@ -88,12 +176,50 @@ func run(logger *slog.Logger) error {
 		return err
 	}
 
+	jobQueue, err := jobs.New(cfg.jobs.dir, cfg.jobs.ttl)
+	if err != nil {
+		return err
+	}
+
+	imageCache, err := imageproxy.NewCache(cfg.images.cacheDir, int64(cfg.images.cacheSize))
+	if err != nil {
+		return err
+	}
+
+	hub := events.NewHub()
+
+	jobQueue.OnUpdate(func(job jobs.Job) {
+		hub.Publish("jobs:"+job.ID, "job", job)
+	})
+
 	app := &application{
 		config: cfg,
 		db:     db,
 		logger: logger,
 		mailer: mailer,
+		jobs:   jobQueue,
+		images: imageproxy.New(cfg.images.hosts, imageCache),
+		events: hub,
+	}
+
+	app.graphqlSchema, err = app.newGraphQLSchema()
+	if err != nil {
+		return err
 	}
 
 	return app.serveHTTP()
 }
+
+// splitList splits a comma-separated setting, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/database"
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	"miruchigawa.moe/restapi/internal/funcs/mapping"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+const mappingMaxAge = time.Hour
+
+// resolveAnimeMapping returns the stored mapping for a provider entry, or
+// works one out from the provider's info page when there isn't one yet. The
+// info page is only fetched when info is nil. Heuristic matches are only
+// stored once they reach the confidence threshold.
+func (app *application) resolveAnimeMapping(provider, id string, info *animeModels.AnimeInfo) (*animeModels.Mapping, error) {
+	stored, found, err := app.db.GetAnimeMapping(provider, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return mappingFromDatabase(stored), nil
+	}
+
+	if info == nil {
+		info, err = anime.Info(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A failed Kitsu lookup doesn't spoil the rest of the match, but it
+	// isn't stored either so that the next lookup tries Kitsu again.
+	result, err := mapping.Match(provider, info)
+	kitsuFailed := errors.Is(err, mapping.ErrKitsu)
+	if kitsuFailed {
+		app.logger.Warn("kitsu lookup failed", "provider", provider, "id", id, "error", err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	result.ProviderID = id
+
+	if result.AniListID != 0 && result.Confidence >= mapping.ConfidenceThreshold {
+		result.Confirmed = true
+
+		if kitsuFailed {
+			return result, nil
+		}
+
+		err := app.db.UpsertAnimeMapping(database.AnimeMapping{
+			Provider:   result.Provider,
+			ProviderID: result.ProviderID,
+			AniListID:  result.AniListID,
+			MalID:      result.MalID,
+			KitsuID:    result.KitsuID,
+			Confidence: result.Confidence,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func mappingFromDatabase(m database.AnimeMapping) *animeModels.Mapping {
+	return &animeModels.Mapping{
+		Provider:   m.Provider,
+		ProviderID: m.ProviderID,
+		AniListID:  m.AniListID,
+		MalID:      m.MalID,
+		KitsuID:    m.KitsuID,
+		Confidence: m.Confidence,
+		Manual:     m.Manual,
+		Confirmed:  true,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"miruchigawa.moe/restapi/internal/response"
 
+	"github.com/gorilla/mux"
 	"github.com/tomasen/realip"
 )
 
@@ -23,6 +32,59 @@ func (app *application) recoverPanic(next http.Handler) http.Handler {
 	})
 }
 
+// requireKnownFormat rejects requests whose format parameter names a response
+// format there is no encoder for, rather than quietly answering in JSON.
+func (app *application) requireKnownFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := response.Negotiate(r); !ok && !chapterArchiveRequested(r) {
+			var names []string
+			for _, e := range response.Encoders() {
+				names = append(names, e.Name)
+			}
+
+			app.errorMessage(w, r, http.StatusNotAcceptable, "format must be one of "+strings.Join(names, ", "), nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// chapterArchiveRequested reports whether r is a chapter download whose format
+// parameter names an archive format rather than a response format.
+func chapterArchiveRequested(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil || route.GetName() != chapterDownloadRoute {
+		return false
+	}
+
+	_, ok := chapterFormats[strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))]
+	return ok
+}
+
+var rgxRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestID tags each request with an ID, reusing the client's X-Request-ID
+// when it sent a sensible one, and echoes it back so logs and client reports
+// can be matched up.
+func (app *application) requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !rgxRequestID.MatchString(id) {
+			b := make([]byte, 16)
+			_, err := rand.Read(b)
+			if err != nil {
+				app.serverError(w, r, err)
+				return
+			}
+			id = hex.EncodeToString(b)
+		}
+
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, response.ContextSetRequestID(r, id))
+	})
+}
+
 func (app *application) logAccess(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		mw := response.NewMetricsResponseWriter(w)
@@ -31,14 +93,188 @@ func (app *application) logAccess(next http.Handler) http.Handler {
 		var (
 			ip     = realip.FromRequest(r)
 			method = r.Method
-			url    = r.URL.String()
+			url    = redactedURL(r.URL)
 			proto  = r.Proto
 		)
 
 		userAttrs := slog.Group("user", "ip", ip)
-		requestAttrs := slog.Group("request", "method", method, "url", url, "proto", proto)
+		requestAttrs := slog.Group("request", "id", response.RequestID(r), "method", method, "url", url, "proto", proto)
 		responseAttrs := slog.Group("repsonse", "status", mw.StatusCode, "size", mw.BytesCount)
 
 		app.logger.Info("access", userAttrs, requestAttrs, responseAttrs)
 	})
 }
+
+// compressResponse adds ETags and conditional request handling to responses
+// and compresses those over the configured minimum size.
+func (app *application) compressResponse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cw := response.NewCompressWriter(w, r, app.config.compression.minSize)
+		next.ServeHTTP(cw, r)
+
+		err := cw.Close()
+		if err != nil {
+			app.serverError(w, r, err)
+		}
+	})
+}
+
+func (app *application) securityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// cors adds the CORS headers for requests from allowed origins. Preflight
+// requests are answered by unmatchedRoute.
+func (app *application) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || len(app.config.cors.allowedOrigins) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+
+		if app.originAllowed(origin) {
+			if app.config.cors.allowCredentials || !slices.Contains(app.config.cors.allowedOrigins, "*") {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+
+			if app.config.cors.allowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if len(app.config.cors.exposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(app.config.cors.exposedHeaders, ", "))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// unmatchedRoute handles requests the router couldn't route. Paths which
+// exist for other methods get a 405 with an Allow header, or, for OPTIONS, a
+// 204 plus the CORS preflight headers when it is a preflight request. No
+// route accepts OPTIONS, and mux reports a method mismatch within a subrouter
+// as not found, so this serves as both its not found and method not allowed
+// handler.
+func (app *application) unmatchedRoute(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			req := r.Clone(r.Context())
+			req.Method = method
+
+			var match mux.RouteMatch
+			if router.Match(req, &match) && match.MatchErr == nil {
+				allowed = append(allowed, method)
+			}
+		}
+
+		if len(allowed) == 0 {
+			app.notFound(w, r)
+			return
+		}
+
+		w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+
+		if r.Method != http.MethodOptions {
+			app.methodNotAllowed(w, r)
+			return
+		}
+
+		if w.Header().Get("Access-Control-Allow-Origin") != "" && r.Header.Get("Access-Control-Request-Method") != "" {
+			methods := []string{}
+			for _, method := range allowed {
+				if slices.Contains(app.config.cors.allowedMethods, method) {
+					methods = append(methods, method)
+				}
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(app.config.cors.allowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(app.config.cors.maxAge.Seconds())))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// originAllowed matches an origin against the configured origins, where "*"
+// allows any origin and a "*" within an origin matches one or more
+// characters, as in "https://*.example.org".
+func (app *application) originAllowed(origin string) bool {
+	for _, allowed := range app.config.cors.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		prefix, suffix, ok := strings.Cut(allowed, "*")
+		if ok && len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+			strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactedURL hides tokens given in the query string, as WebSocket clients in
+// browsers have to, from the access log.
+func redactedURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("token") {
+		return u.String()
+	}
+
+	query.Set("token", "REDACTED")
+
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func (app *application) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if app.config.admin.token == "" {
+			app.notFound(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(app.config.admin.token)) != 1 {
+			app.invalidAuthenticationToken(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
+
+// validClientToken reports whether token is one of the tokens issued to
+// clients of the WebSocket and gRPC APIs.
+func (app *application) validClientToken(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	for _, valid := range app.config.clients.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(valid)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
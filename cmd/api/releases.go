@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/database"
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+func (app *application) releaseChecker(ctx context.Context) {
+	defer app.wg.Done()
+
+	ticker := time.NewTicker(app.config.releases.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		err := app.checkReleases()
+		if err != nil {
+			app.logger.Error(err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReleases records the episodes on the first page of recent releases
+// which haven't been seen before. The first check against an empty table only
+// seeds it, since none of those episodes were released just now.
+func (app *application) checkReleases() error {
+	seeded, err := app.db.HasEpisodeReleases()
+	if err != nil {
+		return err
+	}
+
+	for _, releaseType := range []animeModels.ReleaseType{animeModels.RELEASE_SUB, animeModels.RELEASE_DUB} {
+		result, err := anime.RecentReleases(1, releaseType)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.recordReleases(result.Results, seeded)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordReleases stores new releases as released now and, if publish is set,
+// announces them to subscribers.
+func (app *application) recordReleases(releases []animeModels.RecentRelease, publish bool) ([]database.EpisodeRelease, error) {
+	now := time.Now()
+	records := make([]database.EpisodeRelease, 0, len(releases))
+
+	for _, release := range releases {
+		records = append(records, database.EpisodeRelease{
+			EpisodeID:     release.EpisodeID,
+			AnimeID:       release.ID,
+			Title:         release.Title,
+			Image:         release.Image,
+			EpisodeNumber: release.EpisodeNumber,
+			SubOrDub:      string(release.SubOrDub),
+			ReleasedAt:    now,
+		})
+	}
+
+	inserted, err := app.db.InsertEpisodeReleases(records)
+	if err != nil || !publish {
+		return inserted, err
+	}
+
+	for _, record := range inserted {
+		for _, release := range releases {
+			if release.EpisodeID == record.EpisodeID {
+				app.events.Publish("releases:recent", "release", release)
+				app.events.Publish("anime:"+release.ID, "release", release)
+				break
+			}
+		}
+	}
+
+	return inserted, nil
+}
@@ -3,24 +3,73 @@ package main
 import (
 	"net/http"
 
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	"miruchigawa.moe/restapi/internal/funcs/downloader"
+
 	"github.com/gorilla/mux"
 )
 
 func (app *application) routes() http.Handler {
 	mux := mux.NewRouter()
 
-	mux.NotFoundHandler = http.HandlerFunc(app.notFound)
-	mux.MethodNotAllowedHandler = http.HandlerFunc(app.methodNotAllowed)
+	mux.NotFoundHandler = app.unmatchedRoute(mux)
+	mux.MethodNotAllowedHandler = app.unmatchedRoute(mux)
+
+	mux.Use(app.recoverPanic, app.requireKnownFormat)
+
+	// Batch sub-requests go through the whole handler, which only exists
+	// once every route has been registered.
+	var handler http.Handler
+	root := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	})
 
-	mux.Use(app.logAccess)
-	mux.Use(app.recoverPanic)
+	// The unprefixed paths are the original API and stay on the v1 format,
+	// as does /v1. /v2 serves the same routes with the response envelope.
+	app.apiRoutes(root, mux.PathPrefix("/v2").Subrouter())
+	app.apiRoutes(root, mux.PathPrefix("/v1").Subrouter())
+	app.apiRoutes(root, mux)
+
+	// These wrap the router rather than being added with Use so that they
+	// also apply to the not found and method not allowed handlers. Access
+	// logging sits outside compression to record what was actually sent.
+	handler = app.requestID(app.logAccess(app.securityHeaders(app.cors(app.compressResponse(mux)))))
+
+	return handler
+}
 
+// apiRoutes registers the API on mux. Batch sub-requests are dispatched
+// through root so that they can target any prefix.
+func (app *application) apiRoutes(root http.Handler, mux *mux.Router) {
 	mux.HandleFunc("/status", app.status).Methods("GET")
+	mux.HandleFunc("/search", app.unifiedSearch).Methods("GET")
+	mux.HandleFunc("/catalog/search", app.catalogSearch).Methods("GET")
 	mux.HandleFunc("/anime/search", app.animeSearch).Methods("GET")
 	mux.HandleFunc("/anime/info", app.animeInfo).Methods("GET")
+	mux.HandleFunc("/anime/genres", app.animeGenres).Methods("GET")
+	mux.HandleFunc("/anime/schedule", app.animeSchedule).Methods("GET")
+	mux.HandleFunc("/anime/mapping", app.animeMapping).Methods("GET")
+	mux.HandleFunc("/anime/recent", app.animeRecent).Methods("GET")
+	mux.HandleFunc("/anime/popular", app.animeListing(anime.Popular)).Methods("GET")
+	mux.HandleFunc("/anime/new-season", app.animeListing(anime.NewSeason)).Methods("GET")
+	mux.HandleFunc("/anime/ongoing", app.animeListing(anime.Ongoing)).Methods("GET")
+	mux.HandleFunc("/anime/completed", app.animeListing(anime.Completed)).Methods("GET")
 	mux.HandleFunc("/manga/search", app.mangaSearch).Methods("GET")
+	mux.HandleFunc("/manga/tags", app.mangaTags).Methods("GET")
+	mux.HandleFunc("/manga/chapter/download", app.mangaChapterDownload).Methods("GET").Name(chapterDownloadRoute)
+	mux.HandleFunc("/jobs/{id}", app.jobStatus).Methods("GET")
+	mux.HandleFunc("/jobs/{id}/download", app.jobDownload).Methods("GET")
+	mux.HandleFunc("/ws", app.websocket).Methods("GET")
+	mux.HandleFunc("/graphql", app.graphql).Methods("GET", "POST")
+	mux.HandleFunc("/batch", app.batch(root)).Methods("POST")
+	mux.HandleFunc("/img", app.imageProxy).Methods("GET")
+	mux.HandleFunc("/downloader", app.downloader).Methods("GET")
 	mux.HandleFunc("/downloader/mediafire", app.mediafire).Methods("GET")
 	mux.HandleFunc("/downloader/tiktok", app.tiktokDownloader).Methods("GET")
+	mux.HandleFunc("/downloader/instagram", app.socialDownloader(downloader.IsInstagramURL, downloader.InstagramDownloader)).Methods("GET")
+	mux.HandleFunc("/downloader/twitter", app.socialDownloader(downloader.IsTwitterURL, downloader.TwitterDownloader)).Methods("GET")
+	mux.HandleFunc("/downloader/youtube", app.socialDownloader(downloader.IsYoutubeShortURL, downloader.YoutubeShort)).Methods("GET")
 
-	return mux
+	mux.HandleFunc("/admin/anime/mapping", app.requireAdmin(app.adminPutAnimeMapping)).Methods("PUT")
+	mux.HandleFunc("/admin/anime/mapping", app.requireAdmin(app.adminDeleteAnimeMapping)).Methods("DELETE")
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -29,6 +30,66 @@ func (app *application) serveHTTP() error {
 		WriteTimeout: defaultWriteTimeout,
 	}
 
+	// Hijacked WebSocket connections aren't closed by Shutdown.
+	srv.RegisterOnShutdown(app.events.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if app.config.releases.checkInterval > 0 {
+		app.wg.Add(1)
+		go app.releaseChecker(ctx)
+	}
+
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.jobs.Run(ctx, app.config.jobs.workers)
+	}()
+
+	stopGRPC := func(context.Context) {}
+
+	if app.config.grpcPort > 0 {
+		addr := fmt.Sprintf(":%d", app.config.grpcPort)
+
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		grpcSrv := app.newGRPCServer()
+
+		app.wg.Add(1)
+		go func() {
+			defer app.wg.Done()
+
+			app.logger.Info("starting grpc server", slog.Group("server", "addr", addr))
+
+			err := grpcSrv.Serve(lis)
+			if err != nil {
+				app.logger.Error(err.Error())
+			}
+
+			app.logger.Info("stopped grpc server", slog.Group("server", "addr", addr))
+		}()
+
+		// GracefulStop waits for every in-flight call, so fall back to Stop
+		// once the shutdown period runs out.
+		stopGRPC = func(ctx context.Context) {
+			stopped := make(chan struct{})
+			go func() {
+				grpcSrv.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcSrv.Stop()
+			}
+		}
+	}
+
 	shutdownErrorChan := make(chan error)
 
 	go func() {
@@ -39,6 +100,7 @@ func (app *application) serveHTTP() error {
 		ctx, cancel := context.WithTimeout(context.Background(), defaultShutdownPeriod)
 		defer cancel()
 
+		stopGRPC(ctx)
 		shutdownErrorChan <- srv.Shutdown(ctx)
 	}()
 
@@ -56,6 +118,7 @@ func (app *application) serveHTTP() error {
 
 	app.logger.Info("stopped server", slog.Group("server", "addr", srv.Addr))
 
+	cancel()
 	app.wg.Wait()
 	return nil
 }
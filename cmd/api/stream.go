@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	"miruchigawa.moe/restapi/internal/funcs/search"
+	animeModels "miruchigawa.moe/restapi/internal/models/anime"
+	searchModels "miruchigawa.moe/restapi/internal/models/search"
+	"miruchigawa.moe/restapi/internal/response"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+type streamEvent struct {
+	name string
+	data any
+}
+
+// streamEvents runs produce in the background and sends each event it emits to
+// the client as a Server-Sent Event, with heartbeats while it works. The
+// context passed to produce is cancelled when the client disconnects. The
+// stream ends with a "done" event carrying the value produce returns, or an
+// "error" event if it fails.
+func (app *application) streamEvents(w http.ResponseWriter, r *http.Request, produce func(ctx context.Context, emit func(event string, data any)) (any, error)) {
+	stream, err := response.NewEventStream(w, r)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var (
+		events     = make(chan streamEvent)
+		done       = make(chan struct{})
+		result     any
+		produceErr error
+	)
+
+	emit := func(event string, data any) {
+		select {
+		case events <- streamEvent{name: event, data: data}:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(done)
+
+		defer func() {
+			err := recover()
+			if err != nil {
+				produceErr = fmt.Errorf("%s", err)
+			}
+		}()
+
+		result, produceErr = produce(ctx, emit)
+	}()
+
+	ticker := time.NewTicker(streamHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+
+		select {
+		case event := <-events:
+			err = stream.Send(event.name, event.data)
+		case <-ticker.C:
+			err = stream.Heartbeat()
+		case <-ctx.Done():
+			return
+		case <-done:
+			if produceErr != nil {
+				if ctx.Err() == nil {
+					app.reportServerError(r, produceErr)
+				}
+
+				stream.Send("error", response.Error{
+					Code:    response.ErrorCode(http.StatusInternalServerError),
+					Message: "The server encountered a problem and could not process your request",
+				})
+				return
+			}
+
+			stream.Send("done", result)
+			return
+		}
+
+		// A failed write means the client has gone away.
+		if err != nil {
+			return
+		}
+	}
+}
+
+// animeInfoStream sends the base info as soon as the category page has been
+// scraped, followed by the episodes a page of the episode list at a time and
+// then the enrichment if asked for.
+func (app *application) animeInfoStream(w http.ResponseWriter, r *http.Request, id string, enriched, proxyImages bool) {
+	app.streamEvents(w, r, func(ctx context.Context, emit func(string, any)) (any, error) {
+		result, err := anime.InfoStream(ctx, id,
+			func(info *animeModels.AnimeInfo) {
+				base := *info
+				base.Episodes = []animeModels.Episode{}
+				emit("info", app.proxiedImages(&base, proxyImages))
+			},
+			func(episodes []animeModels.Episode) {
+				emit("episodes", episodes)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		app.enrichAndCatalogAnimeInfo(r, result, enriched)
+
+		if result.Enrichment != nil {
+			emit("enrichment", result.Enrichment)
+		}
+
+		return struct {
+			TotalEpisodes int `json:"totalEpisodes"`
+		}{
+			TotalEpisodes: result.TotalEpisodes,
+		}, nil
+	})
+}
+
+// unifiedSearchStream sends the results of each source as it answers, then
+// the merged and ranked results.
+func (app *application) unifiedSearchStream(w http.ResponseWriter, r *http.Request, name string, weights map[string]float64) {
+	app.streamEvents(w, r, func(ctx context.Context, emit func(string, any)) (any, error) {
+		result := search.SearchStream(ctx, name, weights, func(source string, results []searchModels.Result, err error) {
+			event := struct {
+				Source  string                `json:"source"`
+				Results []searchModels.Result `json:"results"`
+				Error   string                `json:"error,omitempty"`
+			}{
+				Source:  source,
+				Results: results,
+			}
+
+			if err != nil {
+				event.Results = []searchModels.Result{}
+				event.Error = err.Error()
+			}
+
+			emit("source", event)
+		})
+
+		app.backgroundTask(r, func() error {
+			return app.catalogSearchResults(result.Results)
+		})
+
+		return result, nil
+	})
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/events"
+	"miruchigawa.moe/restapi/internal/response"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 4096
+	wsSendBuffer     = 64
+	wsMaxTopics      = 100
+)
+
+// Nothing publishes to manga topics yet, but they are accepted so clients
+// don't need changing once something does.
+var rgxTopic = regexp.MustCompile(`^(?:(?:anime|manga|jobs):[A-Za-z0-9._-]{1,128}|releases:recent)$`)
+
+// wsMessage is a message sent to a WebSocket client.
+type wsMessage struct {
+	Type    string     `json:"type"`
+	Topics  []string   `json:"topics,omitempty"`
+	Topic   string     `json:"topic,omitempty"`
+	Event   string     `json:"event,omitempty"`
+	Data    any        `json:"data,omitempty"`
+	Time    *time.Time `json:"time,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// websocket upgrades the request to a WebSocket over which the client can
+// subscribe to topics by sending {"type":"subscribe","topics":[...]}, and
+// unsubscribe the same way. Events on subscribed topics are sent as they are
+// published. A client that can't keep up is disconnected rather than being
+// allowed to hold up the others.
+func (app *application) websocket(w http.ResponseWriter, r *http.Request) {
+	if len(app.config.clients.tokens) == 0 {
+		app.notFound(w, r)
+		return
+	}
+
+	// Browsers can't set headers on WebSocket requests, so the token can also
+	// be given in the query string.
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = r.URL.Query().Get("token")
+	}
+
+	if !app.validClientToken(token) {
+		app.invalidAuthenticationToken(w, r)
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" || app.originAllowed(origin) {
+				return true
+			}
+
+			u, err := url.Parse(origin)
+			return err == nil && strings.EqualFold(u.Host, r.Host)
+		},
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already sent an error response.
+		return
+	}
+
+	sub := app.events.Subscribe(wsSendBuffer)
+	replies := make(chan wsMessage, 8)
+	goNames := response.VersionOf(r) == response.V1
+
+	go app.wsWrite(conn, sub, replies, goNames)
+
+	reply := func(msg wsMessage) {
+		select {
+		case replies <- msg:
+		default:
+			sub.Close()
+		}
+	}
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				app.logger.Debug("websocket closed", "id", response.RequestID(r), "error", err.Error())
+			}
+			break
+		}
+
+		var msg struct {
+			Type   string   `json:"type"`
+			Topics []string `json:"topics"`
+		}
+
+		err = json.Unmarshal(data, &msg)
+		if err != nil {
+			reply(wsMessage{Type: "error", Message: "Messages must be JSON objects"})
+			continue
+		}
+
+		invalid := ""
+		for _, topic := range msg.Topics {
+			if !rgxTopic.MatchString(topic) {
+				invalid = topic
+				break
+			}
+		}
+
+		switch {
+		case invalid != "":
+			reply(wsMessage{Type: "error", Message: "Unknown topic " + invalid})
+		case len(msg.Topics) == 0:
+			reply(wsMessage{Type: "error", Message: "topics can't be empty"})
+		case msg.Type == "subscribe":
+			if sub.Len()+len(msg.Topics) > wsMaxTopics {
+				reply(wsMessage{Type: "error", Message: "A connection can't subscribe to more than 100 topics"})
+				continue
+			}
+
+			sub.Add(msg.Topics...)
+			reply(wsMessage{Type: "subscribed", Topics: msg.Topics})
+		case msg.Type == "unsubscribe":
+			sub.Remove(msg.Topics...)
+			reply(wsMessage{Type: "unsubscribed", Topics: msg.Topics})
+		default:
+			reply(wsMessage{Type: "error", Message: "type must be subscribe or unsubscribe"})
+		}
+	}
+
+	sub.Close()
+}
+
+// wsWrite is the only writer to conn. It sends events, replies and pings
+// until the subscription is closed, then closes the connection.
+func (app *application) wsWrite(conn *websocket.Conn, sub *events.Subscription, replies <-chan wsMessage, goNames bool) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	defer conn.Close()
+
+	for {
+		var msg wsMessage
+
+		select {
+		case event, ok := <-sub.C():
+			if !ok {
+				code, text := websocket.CloseGoingAway, ""
+				if sub.Dropped() {
+					code, text = websocket.ClosePolicyViolation, "client is too slow"
+				}
+
+				conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+				return
+			}
+
+			msg = wsMessage{Type: "event", Topic: event.Topic, Event: event.Name, Data: event.Data, Time: &event.Time}
+		case msg = <-replies:
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				sub.Close()
+				return
+			}
+			continue
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := conn.WriteJSON(response.Tree(msg, goNames)); err != nil {
+			sub.Close()
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+type Book struct {
+	ID          string
+	Title       string
+	Series      string
+	Number      string
+	Volume      string
+	Language    string
+	Summary     string
+	Web         string
+	Groups      []string
+	RightToLeft bool
+	Chapters    []Chapter
+}
+
+type Chapter struct {
+	Title string
+	Pages [][]byte
+}
+
+type page struct {
+	index     int
+	chapter   int
+	name      string
+	mediaType string
+	width     int
+	height    int
+	data      []byte
+}
+
+// pages flattens the chapters of a book into a single run of pages named in
+// reading order.
+func (b Book) pages() []page {
+	var pages []page
+
+	for c, chapter := range b.Chapters {
+		for _, data := range chapter.Pages {
+			mediaType := http.DetectContentType(data)
+
+			p := page{
+				index:     len(pages),
+				chapter:   c,
+				name:      fmt.Sprintf("%04d%s", len(pages)+1, extension(mediaType)),
+				mediaType: mediaType,
+				width:     800,
+				height:    1200,
+				data:      data,
+			}
+
+			if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+				p.width, p.height = cfg.Width, cfg.Height
+			}
+
+			pages = append(pages, p)
+		}
+	}
+
+	return pages
+}
+
+func extension(mediaType string) string {
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".jpg"
+	}
+}
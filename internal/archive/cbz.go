@@ -0,0 +1,100 @@
+package archive
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+type comicInfo struct {
+	XMLName         xml.Name    `xml:"ComicInfo"`
+	XMLNSXsi        string      `xml:"xmlns:xsi,attr"`
+	XMLNSXsd        string      `xml:"xmlns:xsd,attr"`
+	Title           string      `xml:"Title,omitempty"`
+	Series          string      `xml:"Series,omitempty"`
+	Number          string      `xml:"Number,omitempty"`
+	Volume          string      `xml:"Volume,omitempty"`
+	Summary         string      `xml:"Summary,omitempty"`
+	Web             string      `xml:"Web,omitempty"`
+	PageCount       int         `xml:"PageCount"`
+	LanguageISO     string      `xml:"LanguageISO,omitempty"`
+	Manga           string      `xml:"Manga"`
+	ScanInformation string      `xml:"ScanInformation,omitempty"`
+	Pages           []comicPage `xml:"Pages>Page"`
+}
+
+type comicPage struct {
+	Image       int    `xml:"Image,attr"`
+	Type        string `xml:"Type,attr,omitempty"`
+	ImageSize   int    `xml:"ImageSize,attr"`
+	ImageWidth  int    `xml:"ImageWidth,attr"`
+	ImageHeight int    `xml:"ImageHeight,attr"`
+}
+
+// WriteCBZ writes the book to w as a comic book archive with the pages in
+// reading order followed by a ComicInfo.xml describing them.
+func WriteCBZ(w io.Writer, book Book) error {
+	zw := zip.NewWriter(w)
+	pages := book.pages()
+
+	info := comicInfo{
+		XMLNSXsi:        "http://www.w3.org/2001/XMLSchema-instance",
+		XMLNSXsd:        "http://www.w3.org/2001/XMLSchema",
+		Title:           book.Title,
+		Series:          book.Series,
+		Number:          book.Number,
+		Volume:          book.Volume,
+		Summary:         book.Summary,
+		Web:             book.Web,
+		PageCount:       len(pages),
+		LanguageISO:     book.Language,
+		Manga:           "Yes",
+		ScanInformation: strings.Join(book.Groups, ", "),
+	}
+
+	if book.RightToLeft {
+		info.Manga = "YesAndRightToLeft"
+	}
+
+	for _, p := range pages {
+		// Pages are already compressed images, so they're stored as-is.
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: p.name, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+
+		if _, err := fw.Write(p.data); err != nil {
+			return err
+		}
+
+		cp := comicPage{
+			Image:       p.index,
+			ImageSize:   len(p.data),
+			ImageWidth:  p.width,
+			ImageHeight: p.height,
+		}
+		if p.index == 0 {
+			cp.Type = "FrontCover"
+		}
+
+		info.Pages = append(info.Pages, cp)
+	}
+
+	fw, err := zw.Create("ComicInfo.xml")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(fw, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(fw)
+	enc.Indent("", "  ")
+	if err := enc.Encode(info); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
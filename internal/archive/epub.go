@@ -0,0 +1,195 @@
+package archive
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var epubFuncs = template.FuncMap{
+	"xml": func(s string) (string, error) {
+		var b strings.Builder
+		err := xml.EscapeText(&b, []byte(s))
+		return b.String(), err
+	},
+}
+
+var containerTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+var packageTemplate = template.Must(template.New("package").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid" prefix="rendition: http://www.idpf.org/vocab/rendition/#">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">{{xml .Book.ID}}</dc:identifier>
+    <dc:title>{{xml .Book.Title}}</dc:title>
+    <dc:language>{{if .Book.Language}}{{xml .Book.Language}}{{else}}en{{end}}</dc:language>
+    {{- range .Book.Groups}}
+    <dc:contributor>{{xml .}}</dc:contributor>
+    {{- end}}
+    {{- if .Book.Summary}}
+    <dc:description>{{xml .Book.Summary}}</dc:description>
+    {{- end}}
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+    <meta property="rendition:layout">pre-paginated</meta>
+    <meta property="rendition:spread">none</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    {{- range .Pages}}
+    <item id="img{{.Index}}" href="images/{{.Name}}" media-type="{{.MediaType}}"{{if eq .Index 0}} properties="cover-image"{{end}}/>
+    <item id="page{{.Index}}" href="pages/{{.Index}}.xhtml" media-type="application/xhtml+xml"/>
+    {{- end}}
+  </manifest>
+  <spine{{if .Book.RightToLeft}} page-progression-direction="rtl"{{end}}>
+    {{- range .Pages}}
+    <itemref idref="page{{.Index}}"/>
+    {{- end}}
+  </spine>
+</package>
+`))
+
+var navTemplate = template.Must(template.New("nav").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>{{xml .Book.Title}}</title></head>
+<body>
+  <nav epub:type="toc">
+    <ol>
+      {{- range .Chapters}}
+      <li><a href="pages/{{.Page}}.xhtml">{{xml .Title}}</a></li>
+      {{- end}}
+    </ol>
+  </nav>
+</body>
+</html>
+`))
+
+var pageTemplate = template.Must(template.New("page").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head>
+  <title>{{xml .Title}}</title>
+  <meta name="viewport" content="width={{.Width}}, height={{.Height}}"/>
+  <style>html, body { margin: 0; padding: 0; } img { display: block; width: 100%; height: 100%; }</style>
+</head>
+<body>
+  <img src="../images/{{.Name}}" alt="Page {{.Number}}"/>
+</body>
+</html>
+`))
+
+type epubPage struct {
+	Index     int
+	Number    int
+	Name      string
+	MediaType string
+	Title     string
+	Width     int
+	Height    int
+}
+
+type epubChapter struct {
+	Title string
+	Page  int
+}
+
+// WriteEPUB writes the book to w as a fixed-layout EPUB 3 publication with
+// one XHTML page wrapping each image, listed in the spine in reading order.
+func WriteEPUB(w io.Writer, book Book) error {
+	zw := zip.NewWriter(w)
+	pages := book.pages()
+
+	// The mimetype entry has to come first and be stored uncompressed.
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	fw, err = zw.Create("META-INF/container.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, containerTemplate); err != nil {
+		return err
+	}
+
+	var (
+		epubPages    []epubPage
+		epubChapters []epubChapter
+		lastChapter  = -1
+	)
+
+	for _, p := range pages {
+		epubPages = append(epubPages, epubPage{
+			Index:     p.index,
+			Number:    p.index + 1,
+			Name:      p.name,
+			MediaType: p.mediaType,
+			Title:     fmt.Sprintf("%s - Page %d", book.Title, p.index+1),
+			Width:     p.width,
+			Height:    p.height,
+		})
+
+		if p.chapter != lastChapter {
+			title := book.Chapters[p.chapter].Title
+			if title == "" {
+				title = fmt.Sprintf("Chapter %d", p.chapter+1)
+			}
+
+			epubChapters = append(epubChapters, epubChapter{Title: title, Page: p.index})
+			lastChapter = p.chapter
+		}
+	}
+
+	data := map[string]any{
+		"Book":     book,
+		"Pages":    epubPages,
+		"Chapters": epubChapters,
+		"Modified": time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+
+	for name, tmpl := range map[string]*template.Template{"OEBPS/content.opf": packageTemplate, "OEBPS/nav.xhtml": navTemplate} {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+
+		if err := tmpl.Execute(fw, data); err != nil {
+			return err
+		}
+	}
+
+	for i, p := range pages {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: "OEBPS/images/" + p.name, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+
+		if _, err := fw.Write(p.data); err != nil {
+			return err
+		}
+
+		fw, err = zw.Create(fmt.Sprintf("OEBPS/pages/%d.xhtml", p.index))
+		if err != nil {
+			return err
+		}
+
+		if err := pageTemplate.Execute(fw, epubPages[i]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
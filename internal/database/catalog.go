@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type CatalogEntry struct {
+	ID          int64     `db:"id"`
+	Kind        string    `db:"kind"`
+	Source      string    `db:"source"`
+	SourceID    string    `db:"source_id"`
+	Title       string    `db:"title"`
+	AltTitles   string    `db:"alt_titles"`
+	Description string    `db:"description"`
+	Image       string    `db:"image"`
+	Year        int       `db:"year"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	Rank        float64   `db:"rank"`
+}
+
+// UpsertCatalogEntries inserts or refreshes catalog entries. Fields which are
+// empty on the incoming entry (search results carry less detail than info
+// pages) don't overwrite what is already stored.
+func (db *DB) UpsertCatalogEntries(entries []CatalogEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO catalog (kind, source, source_id, title, alt_titles, description, image, year, updated_at)
+		VALUES (:kind, :source, :source_id, :title, :alt_titles, :description, :image, :year, :updated_at)
+		ON CONFLICT (kind, source, source_id) DO UPDATE SET
+			title = CASE WHEN excluded.title != '' THEN excluded.title ELSE catalog.title END,
+			alt_titles = CASE WHEN excluded.alt_titles != '' THEN excluded.alt_titles ELSE catalog.alt_titles END,
+			description = CASE WHEN excluded.description != '' THEN excluded.description ELSE catalog.description END,
+			image = CASE WHEN excluded.image != '' THEN excluded.image ELSE catalog.image END,
+			year = CASE WHEN excluded.year != 0 THEN excluded.year ELSE catalog.year END,
+			updated_at = excluded.updated_at`
+
+	now := time.Now().UTC()
+
+	for _, entry := range entries {
+		if entry.SourceID == "" || entry.Title == "" {
+			continue
+		}
+
+		entry.UpdatedAt = now
+
+		_, err := tx.NamedExecContext(ctx, query, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SearchCatalog runs a prefix search against the catalog full-text index,
+// ranked by BM25 with title matches weighted above alternative titles and
+// descriptions. When nothing matches, each query term is widened to the
+// indexed terms within a small edit distance of it so that typos still find
+// results. An empty kind searches all kinds.
+func (db *DB) SearchCatalog(kind, query string, limit, offset int) ([]CatalogEntry, error) {
+	terms := catalogTerms(query)
+	if len(terms) == 0 {
+		return []CatalogEntry{}, nil
+	}
+
+	groups := make([][]string, len(terms))
+	for i, term := range terms {
+		groups[i] = []string{quoteTerm(term) + "*"}
+	}
+
+	entries, err := db.matchCatalog(kind, groups, limit, offset)
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
+
+	widened := false
+	for i, term := range terms {
+		similar, err := db.similarCatalogTerms(term)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range similar {
+			groups[i] = append(groups[i], quoteTerm(s))
+			widened = true
+		}
+	}
+
+	if !widened {
+		return entries, nil
+	}
+
+	return db.matchCatalog(kind, groups, limit, offset)
+}
+
+func (db *DB) matchCatalog(kind string, groups [][]string, limit, offset int) ([]CatalogEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	clauses := make([]string, len(groups))
+	for i, group := range groups {
+		clauses[i] = "(" + strings.Join(group, " OR ") + ")"
+	}
+
+	query := `
+		SELECT catalog.*, bm25(catalog_fts, 10.0, 5.0, 1.0) AS rank
+		FROM catalog_fts
+		JOIN catalog ON catalog.id = catalog_fts.rowid
+		WHERE catalog_fts MATCH $1 AND ($2 = '' OR catalog.kind = $2)
+		ORDER BY rank
+		LIMIT $3 OFFSET $4`
+
+	entries := []CatalogEntry{}
+
+	err := db.SelectContext(ctx, &entries, query, strings.Join(clauses, " AND "), kind, limit, offset)
+	return entries, err
+}
+
+func (db *DB) similarCatalogTerms(term string) ([]string, error) {
+	length := len([]rune(term))
+
+	maxDistance := 0
+	switch {
+	case length >= 8:
+		maxDistance = 2
+	case length >= 4:
+		maxDistance = 1
+	}
+
+	if maxDistance == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	var candidates []string
+
+	query := `SELECT term FROM catalog_fts_vocab WHERE length(term) BETWEEN $1 AND $2`
+
+	err := db.SelectContext(ctx, &candidates, query, length-maxDistance, length+maxDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	var similar []string
+	for _, candidate := range candidates {
+		if candidate != term && levenshtein(term, candidate) <= maxDistance {
+			similar = append(similar, candidate)
+		}
+	}
+
+	return similar, nil
+}
+
+func catalogTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func quoteTerm(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type AnimeEnrichment struct {
+	AniListID int       `db:"anilist_id"`
+	Data      string    `db:"data"`
+	FetchedAt time.Time `db:"fetched_at"`
+}
+
+func (db *DB) GetAnimeEnrichment(anilistID int) (AnimeEnrichment, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	var enrichment AnimeEnrichment
+
+	query := `SELECT * FROM anime_enrichments WHERE anilist_id = $1`
+
+	err := db.GetContext(ctx, &enrichment, query, anilistID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return enrichment, false, nil
+	}
+
+	return enrichment, true, err
+}
+
+func (db *DB) UpsertAnimeEnrichment(anilistID int, data string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO anime_enrichments (anilist_id, data, fetched_at) VALUES ($1, $2, $3)
+		ON CONFLICT (anilist_id) DO UPDATE SET data = excluded.data, fetched_at = excluded.fetched_at`
+
+	_, err := db.ExecContext(ctx, query, anilistID, data, time.Now().UTC())
+	return err
+}
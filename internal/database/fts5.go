@@ -0,0 +1,10 @@
+//go:build !sqlite_fts5
+
+package database
+
+// The catalog's search index is an FTS5 table, and mattn/go-sqlite3 only
+// compiles FTS5 in with the sqlite_fts5 build tag. Without it the server
+// would build and then fail its migrations at startup, so refuse to build:
+//
+//	go build -tags sqlite_fts5 ./cmd/api
+var _ = build_with_tags_sqlite_fts5
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type AnimeMapping struct {
+	Provider   string    `db:"provider"`
+	ProviderID string    `db:"provider_id"`
+	AniListID  int       `db:"anilist_id"`
+	MalID      int       `db:"mal_id"`
+	KitsuID    int       `db:"kitsu_id"`
+	Confidence float64   `db:"confidence"`
+	Manual     bool      `db:"manual"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+var mappingColumns = map[string]string{
+	"anilist": "anilist_id",
+	"mal":     "mal_id",
+	"kitsu":   "kitsu_id",
+}
+
+func (db *DB) GetAnimeMapping(provider, providerID string) (AnimeMapping, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	var mapping AnimeMapping
+
+	query := `SELECT * FROM anime_mappings WHERE provider = $1 AND provider_id = $2`
+
+	err := db.GetContext(ctx, &mapping, query, provider, providerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return mapping, false, nil
+	}
+
+	return mapping, true, err
+}
+
+// GetAnimeMappingsByExternal returns every provider entry mapped to an
+// external ID. external is one of "anilist", "mal" or "kitsu".
+func (db *DB) GetAnimeMappingsByExternal(external string, id int) ([]AnimeMapping, error) {
+	column, ok := mappingColumns[external]
+	if !ok {
+		return nil, fmt.Errorf("unknown external id source %q", external)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	mappings := []AnimeMapping{}
+
+	query := fmt.Sprintf(`SELECT * FROM anime_mappings WHERE %s = $1 ORDER BY provider, provider_id`, column)
+
+	err := db.SelectContext(ctx, &mappings, query, id)
+	return mappings, err
+}
+
+// UpsertAnimeMapping stores a mapping. Manual mappings always win, so an
+// automatic match never replaces one that was set through the admin API.
+func (db *DB) UpsertAnimeMapping(mapping AnimeMapping) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	mapping.UpdatedAt = time.Now().UTC()
+
+	query := `
+		INSERT INTO anime_mappings (provider, provider_id, anilist_id, mal_id, kitsu_id, confidence, manual, updated_at)
+		VALUES (:provider, :provider_id, :anilist_id, :mal_id, :kitsu_id, :confidence, :manual, :updated_at)
+		ON CONFLICT (provider, provider_id) DO UPDATE SET
+			anilist_id = excluded.anilist_id,
+			mal_id = excluded.mal_id,
+			kitsu_id = excluded.kitsu_id,
+			confidence = excluded.confidence,
+			manual = excluded.manual,
+			updated_at = excluded.updated_at
+		WHERE anime_mappings.manual = FALSE OR excluded.manual = TRUE`
+
+	_, err := db.NamedExecContext(ctx, query, mapping)
+	return err
+}
+
+func (db *DB) DeleteAnimeMapping(provider, providerID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	query := `DELETE FROM anime_mappings WHERE provider = $1 AND provider_id = $2`
+
+	result, err := db.ExecContext(ctx, query, provider, providerID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+type EpisodeRelease struct {
+	EpisodeID     string    `db:"episode_id"`
+	AnimeID       string    `db:"anime_id"`
+	Title         string    `db:"title"`
+	Image         string    `db:"image"`
+	EpisodeNumber float64   `db:"episode_number"`
+	SubOrDub      string    `db:"sub_or_dub"`
+	ReleasedAt    time.Time `db:"released_at"`
+}
+
+// InsertEpisodeReleases stores any releases which haven't been seen before and
+// returns the ones that were actually inserted.
+func (db *DB) InsertEpisodeReleases(releases []EpisodeRelease) ([]EpisodeRelease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT OR IGNORE INTO episode_releases (episode_id, anime_id, title, image, episode_number, sub_or_dub, released_at)
+		VALUES (:episode_id, :anime_id, :title, :image, :episode_number, :sub_or_dub, :released_at)`
+
+	var inserted []EpisodeRelease
+
+	for _, release := range releases {
+		release.ReleasedAt = release.ReleasedAt.UTC()
+
+		result, err := tx.NamedExecContext(ctx, query, release)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if rows > 0 {
+			inserted = append(inserted, release)
+		}
+	}
+
+	return inserted, tx.Commit()
+}
+
+// HasEpisodeReleases reports whether any releases have been stored yet.
+func (db *DB) HasEpisodeReleases() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	var exists bool
+
+	err := db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM episode_releases)`)
+	return exists, err
+}
+
+func (db *DB) GetEpisodeReleasesSince(since time.Time) ([]EpisodeRelease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	var releases []EpisodeRelease
+
+	query := `SELECT * FROM episode_releases WHERE released_at >= $1 ORDER BY released_at`
+
+	err := db.SelectContext(ctx, &releases, query, since.UTC())
+	return releases, err
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is something that happened on a topic, such as "anime:{id}" or
+// "jobs:{id}".
+type Event struct {
+	Topic string    `json:"topic"`
+	Name  string    `json:"event"`
+	Data  any       `json:"data"`
+	Time  time.Time `json:"time"`
+}
+
+// Hub fans published events out to the subscriptions interested in their
+// topic. Publishing never blocks: a subscription whose buffer is full is
+// dropped, and its channel closed, rather than holding everyone else up.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: map[*Subscription]struct{}{}}
+}
+
+// Subscribe returns a subscription with no topics whose channel holds up to
+// buffer events.
+func (h *Hub) Subscribe(buffer int) *Subscription {
+	s := &Subscription{
+		hub:    h,
+		events: make(chan Event, buffer),
+		topics: map[string]bool{},
+	}
+
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+
+	return s
+}
+
+func (h *Hub) Publish(topic, name string, data any) {
+	event := Event{Topic: topic, Name: name, Data: data, Time: time.Now().UTC()}
+
+	h.mu.RLock()
+	var slow []*Subscription
+	for s := range h.subs {
+		if !s.has(topic) {
+			continue
+		}
+
+		select {
+		case s.events <- event:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, s := range slow {
+		s.close(true)
+	}
+}
+
+// Close closes every subscription, such as when the server shuts down.
+func (h *Hub) Close() {
+	h.mu.RLock()
+	subs := make([]*Subscription, 0, len(h.subs))
+	for s := range h.subs {
+		subs = append(subs, s)
+	}
+	h.mu.RUnlock()
+
+	for _, s := range subs {
+		s.Close()
+	}
+}
+
+// Subscription receives the events for its topics on C until it is closed.
+type Subscription struct {
+	hub     *Hub
+	events  chan Event
+	mu      sync.RWMutex
+	topics  map[string]bool
+	closed  bool
+	dropped bool
+}
+
+func (s *Subscription) C() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Add(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, topic := range topics {
+		s.topics[topic] = true
+	}
+}
+
+func (s *Subscription) Remove(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+}
+
+func (s *Subscription) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.topics)
+}
+
+// Dropped reports whether the subscription was closed for falling behind.
+func (s *Subscription) Dropped() bool {
+	s.hub.mu.RLock()
+	defer s.hub.mu.RUnlock()
+
+	return s.dropped
+}
+
+func (s *Subscription) Close() {
+	s.close(false)
+}
+
+func (s *Subscription) close(dropped bool) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	s.dropped = dropped
+	delete(s.hub.subs, s)
+	close(s.events)
+}
+
+func (s *Subscription) has(topic string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.topics[topic]
+}
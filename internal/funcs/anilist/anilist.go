@@ -0,0 +1,262 @@
+package anilist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIURL is the GraphQL endpoint queried by this package. It can be pointed at
+// any AniList-compatible server.
+var APIURL = "https://graphql.anilist.co"
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+type Title struct {
+	Romaji  string `json:"romaji"`
+	English string `json:"english"`
+	Native  string `json:"native"`
+}
+
+type FuzzyDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type Media struct {
+	ID         int       `json:"id"`
+	IDMal      int       `json:"idMal"`
+	Title      Title     `json:"title"`
+	Synonyms   []string  `json:"synonyms"`
+	Format     string    `json:"format"`
+	Episodes   int       `json:"episodes"`
+	SeasonYear int       `json:"seasonYear"`
+	StartDate  FuzzyDate `json:"startDate"`
+}
+
+func (m Media) Titles() []string {
+	var titles []string
+	for _, title := range append([]string{m.Title.Romaji, m.Title.English, m.Title.Native}, m.Synonyms...) {
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+func (m Media) Year() int {
+	if m.SeasonYear != 0 {
+		return m.SeasonYear
+	}
+	return m.StartDate.Year
+}
+
+const searchQuery = `
+query ($search: String) {
+	Page(perPage: 10) {
+		media(search: $search, type: ANIME) {
+			id
+			idMal
+			title { romaji english native }
+			synonyms
+			format
+			episodes
+			seasonYear
+			startDate { year month day }
+		}
+	}
+}`
+
+func SearchAnime(search string) ([]Media, error) {
+	var data struct {
+		Page struct {
+			Media []Media `json:"media"`
+		} `json:"Page"`
+	}
+
+	err := Query(searchQuery, map[string]any{"search": search}, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Page.Media, nil
+}
+
+// Query sends a GraphQL query to APIURL and decodes the data field of the
+// response into dst.
+func Query(query string, variables map[string]any, dst any) error {
+	body, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, APIURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("anilist: failed to decode response, status code: %d", resp.StatusCode)
+	}
+
+	if len(response.Errors) > 0 {
+		return errors.New("anilist: " + response.Errors[0].Message)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("anilist: unexpected status code: %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(response.Data, dst)
+}
+
+type Details struct {
+	ID           int       `json:"id"`
+	IDMal        int       `json:"idMal"`
+	Title        Title     `json:"title"`
+	Format       string    `json:"format"`
+	Status       string    `json:"status"`
+	Episodes     int       `json:"episodes"`
+	Duration     int       `json:"duration"`
+	Season       string    `json:"season"`
+	SeasonYear   int       `json:"seasonYear"`
+	StartDate    FuzzyDate `json:"startDate"`
+	EndDate      FuzzyDate `json:"endDate"`
+	AverageScore int       `json:"averageScore"`
+	MeanScore    int       `json:"meanScore"`
+	Popularity   int       `json:"popularity"`
+	BannerImage  string    `json:"bannerImage"`
+	CoverImage   struct {
+		ExtraLarge string `json:"extraLarge"`
+		Large      string `json:"large"`
+		Color      string `json:"color"`
+	} `json:"coverImage"`
+	Trailer *struct {
+		ID        string `json:"id"`
+		Site      string `json:"site"`
+		Thumbnail string `json:"thumbnail"`
+	} `json:"trailer"`
+	NextAiringEpisode *struct {
+		AiringAt int64 `json:"airingAt"`
+		Episode  int   `json:"episode"`
+	} `json:"nextAiringEpisode"`
+	Studios struct {
+		Edges []struct {
+			IsMain bool `json:"isMain"`
+			Node   struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"studios"`
+	Characters struct {
+		Edges []struct {
+			Role string `json:"role"`
+			Node struct {
+				ID   int `json:"id"`
+				Name struct {
+					Full string `json:"full"`
+				} `json:"name"`
+				Image struct {
+					Large string `json:"large"`
+				} `json:"image"`
+			} `json:"node"`
+			VoiceActors []struct {
+				ID   int `json:"id"`
+				Name struct {
+					Full string `json:"full"`
+				} `json:"name"`
+				LanguageV2 string `json:"languageV2"`
+			} `json:"voiceActors"`
+		} `json:"edges"`
+	} `json:"characters"`
+	Relations struct {
+		Edges []struct {
+			RelationType string `json:"relationType"`
+			Node         struct {
+				ID     int    `json:"id"`
+				IDMal  int    `json:"idMal"`
+				Type   string `json:"type"`
+				Format string `json:"format"`
+				Title  Title  `json:"title"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"relations"`
+}
+
+const detailsQuery = `
+query ($id: Int) {
+	Media(id: $id, type: ANIME) {
+		id
+		idMal
+		title { romaji english native }
+		format
+		status
+		episodes
+		duration
+		season
+		seasonYear
+		startDate { year month day }
+		endDate { year month day }
+		averageScore
+		meanScore
+		popularity
+		bannerImage
+		coverImage { extraLarge large color }
+		trailer { id site thumbnail }
+		nextAiringEpisode { airingAt episode }
+		studios { edges { isMain node { id name } } }
+		characters(sort: [ROLE, RELEVANCE], perPage: 25) {
+			edges {
+				role
+				node { id name { full } image { large } }
+				voiceActors(language: JAPANESE) { id name { full } languageV2 }
+			}
+		}
+		relations {
+			edges {
+				relationType
+				node { id idMal type format title { romaji english native } }
+			}
+		}
+	}
+}`
+
+func AnimeDetails(id int) (*Details, error) {
+	var data struct {
+		Media *Details `json:"Media"`
+	}
+
+	err := Query(detailsQuery, map[string]any{"id": id}, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Media == nil {
+		return nil, fmt.Errorf("anilist: media %d not found", id)
+	}
+
+	return data.Media, nil
+}
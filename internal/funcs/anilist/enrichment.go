@@ -0,0 +1,126 @@
+package anilist
+
+import (
+	"fmt"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+// Enrichment fetches the AniList entry for an anime and converts it into the
+// fields that are merged into a scraped AnimeInfo.
+func Enrichment(id int) (*models.Enrichment, error) {
+	details, err := AnimeDetails(id)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichment := &models.Enrichment{
+		AniListID:    details.ID,
+		MalID:        details.IDMal,
+		Format:       details.Format,
+		Status:       details.Status,
+		Season:       details.Season,
+		SeasonYear:   details.SeasonYear,
+		Episodes:     details.Episodes,
+		Duration:     details.Duration,
+		StartDate:    details.StartDate.String(),
+		EndDate:      details.EndDate.String(),
+		AverageScore: details.AverageScore,
+		MeanScore:    details.MeanScore,
+		Popularity:   details.Popularity,
+		BannerImage:  details.BannerImage,
+		CoverImage:   details.CoverImage.ExtraLarge,
+		Color:        details.CoverImage.Color,
+		Studios:      []models.Studio{},
+		Characters:   []models.Character{},
+		Relations:    []models.Relation{},
+	}
+
+	if enrichment.CoverImage == "" {
+		enrichment.CoverImage = details.CoverImage.Large
+	}
+
+	if details.Trailer != nil {
+		trailer := &models.Trailer{
+			ID:        details.Trailer.ID,
+			Site:      details.Trailer.Site,
+			Thumbnail: details.Trailer.Thumbnail,
+		}
+
+		switch details.Trailer.Site {
+		case "youtube":
+			trailer.URL = "https://www.youtube.com/watch?v=" + details.Trailer.ID
+		case "dailymotion":
+			trailer.URL = "https://www.dailymotion.com/video/" + details.Trailer.ID
+		}
+
+		enrichment.Trailer = trailer
+	}
+
+	if details.NextAiringEpisode != nil {
+		enrichment.NextAiringEpisode = &models.AiringEpisode{
+			Episode:  details.NextAiringEpisode.Episode,
+			AiringAt: time.Unix(details.NextAiringEpisode.AiringAt, 0).UTC(),
+		}
+	}
+
+	for _, edge := range details.Studios.Edges {
+		enrichment.Studios = append(enrichment.Studios, models.Studio{
+			ID:     edge.Node.ID,
+			Name:   edge.Node.Name,
+			IsMain: edge.IsMain,
+		})
+	}
+
+	for _, edge := range details.Characters.Edges {
+		character := models.Character{
+			ID:          edge.Node.ID,
+			Name:        edge.Node.Name.Full,
+			Image:       edge.Node.Image.Large,
+			Role:        edge.Role,
+			VoiceActors: []models.VoiceActor{},
+		}
+
+		for _, va := range edge.VoiceActors {
+			character.VoiceActors = append(character.VoiceActors, models.VoiceActor{
+				ID:       va.ID,
+				Name:     va.Name.Full,
+				Language: va.LanguageV2,
+			})
+		}
+
+		enrichment.Characters = append(enrichment.Characters, character)
+	}
+
+	for _, edge := range details.Relations.Edges {
+		title := edge.Node.Title.English
+		if title == "" {
+			title = edge.Node.Title.Romaji
+		}
+
+		enrichment.Relations = append(enrichment.Relations, models.Relation{
+			AniListID:    edge.Node.ID,
+			MalID:        edge.Node.IDMal,
+			RelationType: edge.RelationType,
+			Type:         edge.Node.Type,
+			Format:       edge.Node.Format,
+			Title:        title,
+		})
+	}
+
+	return enrichment, nil
+}
+
+func (d FuzzyDate) String() string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	}
+}
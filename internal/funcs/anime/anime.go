@@ -1,11 +1,16 @@
 package anime
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 
 	models "miruchigawa.moe/restapi/internal/models/anime"
@@ -16,16 +21,314 @@ var (
 	ajaxURL string = "https://ajax.gogocdn.net/ajax"
 )
 
-func Search(query string, page int) (*models.SearchResult, error) {
+var (
+	// Genres are the genres the site listed when this was written. They
+	// stand in for the scraped list whenever it can't be fetched.
+	Genres = []models.Genre{
+		{ID: "action", Name: "Action"},
+		{ID: "adult-cast", Name: "Adult Cast"},
+		{ID: "adventure", Name: "Adventure"},
+		{ID: "anthropomorphic", Name: "Anthropomorphic"},
+		{ID: "avant-garde", Name: "Avant Garde"},
+		{ID: "boys-love", Name: "Boys Love"},
+		{ID: "cars", Name: "Cars"},
+		{ID: "cgdct", Name: "CGDCT"},
+		{ID: "childcare", Name: "Childcare"},
+		{ID: "comedy", Name: "Comedy"},
+		{ID: "comic", Name: "Comic"},
+		{ID: "crime", Name: "Crime"},
+		{ID: "crossdressing", Name: "Crossdressing"},
+		{ID: "delinquents", Name: "Delinquents"},
+		{ID: "dementia", Name: "Dementia"},
+		{ID: "demons", Name: "Demons"},
+		{ID: "detective", Name: "Detective"},
+		{ID: "drama", Name: "Drama"},
+		{ID: "dub", Name: "Dub"},
+		{ID: "ecchi", Name: "Ecchi"},
+		{ID: "erotica", Name: "Erotica"},
+		{ID: "family", Name: "Family"},
+		{ID: "fantasy", Name: "Fantasy"},
+		{ID: "gag-humor", Name: "Gag Humor"},
+		{ID: "game", Name: "Game"},
+		{ID: "gender-bender", Name: "Gender Bender"},
+		{ID: "gore", Name: "Gore"},
+		{ID: "gourmet", Name: "Gourmet"},
+		{ID: "harem", Name: "Harem"},
+		{ID: "high-stakes-game", Name: "High Stakes Game"},
+		{ID: "historical", Name: "Historical"},
+		{ID: "horror", Name: "Horror"},
+		{ID: "isekai", Name: "Isekai"},
+		{ID: "iyashikei", Name: "Iyashikei"},
+		{ID: "josei", Name: "Josei"},
+		{ID: "kids", Name: "Kids"},
+		{ID: "love-polygon", Name: "Love Polygon"},
+		{ID: "magic", Name: "Magic"},
+		{ID: "magical-sex-shift", Name: "Magical Sex Shift"},
+		{ID: "mahou-shoujo", Name: "Mahou Shoujo"},
+		{ID: "martial-arts", Name: "Martial Arts"},
+		{ID: "mecha", Name: "Mecha"},
+		{ID: "medical", Name: "Medical"},
+		{ID: "military", Name: "Military"},
+		{ID: "music", Name: "Music"},
+		{ID: "mystery", Name: "Mystery"},
+		{ID: "mythology", Name: "Mythology"},
+		{ID: "organized-crime", Name: "Organized Crime"},
+		{ID: "parody", Name: "Parody"},
+		{ID: "performing-arts", Name: "Performing Arts"},
+		{ID: "pets", Name: "Pets"},
+		{ID: "police", Name: "Police"},
+		{ID: "psychological", Name: "Psychological"},
+		{ID: "racing", Name: "Racing"},
+		{ID: "reincarnation", Name: "Reincarnation"},
+		{ID: "romance", Name: "Romance"},
+		{ID: "romantic-subtext", Name: "Romantic Subtext"},
+		{ID: "samurai", Name: "Samurai"},
+		{ID: "school", Name: "School"},
+		{ID: "sci-fi", Name: "Sci-Fi"},
+		{ID: "seinen", Name: "Seinen"},
+		{ID: "shoujo", Name: "Shoujo"},
+		{ID: "shoujo-ai", Name: "Shoujo Ai"},
+		{ID: "shounen", Name: "Shounen"},
+		{ID: "showbiz", Name: "Showbiz"},
+		{ID: "slice-of-life", Name: "Slice of Life"},
+		{ID: "space", Name: "Space"},
+		{ID: "sports", Name: "Sports"},
+		{ID: "strategy-game", Name: "Strategy Game"},
+		{ID: "super-power", Name: "Super Power"},
+		{ID: "supernatural", Name: "Supernatural"},
+		{ID: "survival", Name: "Survival"},
+		{ID: "suspense", Name: "Suspense"},
+		{ID: "team-sports", Name: "Team Sports"},
+		{ID: "thriller", Name: "Thriller"},
+		{ID: "time-travel", Name: "Time Travel"},
+		{ID: "vampire", Name: "Vampire"},
+		{ID: "video-game", Name: "Video Game"},
+		{ID: "visual-arts", Name: "Visual Arts"},
+		{ID: "work-life", Name: "Work Life"},
+		{ID: "workplace", Name: "Workplace"},
+	}
+	Seasons   = []string{"winter", "spring", "summer", "fall"}
+	Statuses  = []string{"ongoing", "completed", "upcoming"}
+	Types     = []string{"tv", "movie", "ova", "ona", "special", "music"}
+	Languages = []string{"sub", "dub"}
+	Sorts     = []string{"title_az", "recently_updated", "recently_added", "release_date"}
+)
+
+var (
+	filterStatuses = map[string]string{
+		"ongoing":   "Ongoing",
+		"completed": "Completed",
+		"upcoming":  "Upcoming",
+	}
+	filterTypes = map[string]string{
+		"tv":      "1",
+		"special": "2",
+		"movie":   "3",
+		"ova":     "26",
+		"ona":     "30",
+		"music":   "32",
+	}
+	filterLanguages = map[string]string{
+		"sub": "subbed",
+		"dub": "dubbed",
+	}
+)
+
+type SearchFilter struct {
+	Keyword  string
+	Genres   []string
+	Year     string
+	Season   string
+	Status   string
+	Type     string
+	Language string
+	Sort     string
+}
+
+func Search(ctx context.Context, query string, page int) (*models.SearchResult, error) {
+	return AdvancedSearch(ctx, SearchFilter{Keyword: query}, page)
+}
+
+func AdvancedSearch(ctx context.Context, filter SearchFilter, page int) (*models.SearchResult, error) {
+	params := url.Values{}
+	params.Set("keyword", filter.Keyword)
+	params.Set("page", strconv.Itoa(page))
+
+	for _, genre := range filter.Genres {
+		params.Add("genre[]", genre)
+	}
+	if filter.Year != "" {
+		params.Set("year[]", filter.Year)
+	}
+	if filter.Season != "" {
+		params.Set("season[]", filter.Season)
+	}
+	if status, ok := filterStatuses[filter.Status]; ok {
+		params.Set("status[]", status)
+	}
+	if mediaType, ok := filterTypes[filter.Type]; ok {
+		params.Set("type[]", mediaType)
+	}
+	if language, ok := filterLanguages[filter.Language]; ok {
+		params.Set("language[]", language)
+	}
+	if filter.Sort != "" {
+		params.Set("sort", filter.Sort)
+	}
+
+	return scrapeListing(ctx, fmt.Sprintf("%s/filter.html?%s", baseURL, params.Encode()), page)
+}
+
+const (
+	genresTTL   = 6 * time.Hour
+	genresRetry = 5 * time.Minute
+)
+
+var genreCache struct {
+	sync.Mutex
+	genres  []models.Genre
+	expires time.Time
+}
+
+// ListGenres returns the genres listed on the site, which are the ones
+// AdvancedSearch can filter by. The list is scraped at most once every
+// genresTTL. When scraping fails the last list scraped is kept, or Genres
+// used if there isn't one, and scraping is tried again after genresRetry.
+func ListGenres() []models.Genre {
+	genreCache.Lock()
+	defer genreCache.Unlock()
+
+	if time.Now().Before(genreCache.expires) {
+		return genreCache.genres
+	}
+
+	genres, err := scrapeGenres()
+	if err != nil || len(genres) == 0 {
+		if genreCache.genres == nil {
+			genreCache.genres = Genres
+		}
+		genreCache.expires = time.Now().Add(genresRetry)
+
+		return genreCache.genres
+	}
+
+	genreCache.genres = genres
+	genreCache.expires = time.Now().Add(genresTTL)
+
+	return genres
+}
+
+// GenreIDs returns the IDs of the genres from ListGenres, which are what
+// SearchFilter takes.
+func GenreIDs() []string {
+	genres := ListGenres()
+
+	ids := make([]string, len(genres))
+	for i, genre := range genres {
+		ids[i] = genre.ID
+	}
+
+	return ids
+}
+
+func scrapeGenres() ([]models.Genre, error) {
+	genres := []models.Genre{}
+
+	c := colly.NewCollector()
+
+	c.OnHTML("nav.menu_series.genre ul > li > a", func(e *colly.HTMLElement) {
+		genre := models.Genre{
+			ID:   strings.TrimPrefix(e.Attr("href"), "/genre/"),
+			Name: strings.TrimSpace(e.Attr("title")),
+		}
+		if genre.Name == "" {
+			genre.Name = strings.TrimSpace(e.Text)
+		}
+		genres = append(genres, genre)
+	})
+
+	if err := c.Visit(baseURL + "/home.html"); err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func Popular(page int) (*models.SearchResult, error) {
+	return scrapeListing(context.Background(), fmt.Sprintf("%s/popular.html?page=%d", baseURL, page), page)
+}
+
+func NewSeason(page int) (*models.SearchResult, error) {
+	return scrapeListing(context.Background(), fmt.Sprintf("%s/new-season.html?page=%d", baseURL, page), page)
+}
+
+func Ongoing(page int) (*models.SearchResult, error) {
+	return scrapeListing(context.Background(), fmt.Sprintf("%s/ongoing-anime.html?page=%d", baseURL, page), page)
+}
+
+func Completed(page int) (*models.SearchResult, error) {
+	return scrapeListing(context.Background(), fmt.Sprintf("%s/completed-anime.html?page=%d", baseURL, page), page)
+}
+
+func RecentReleases(page int, releaseType models.ReleaseType) (*models.RecentReleases, error) {
+	recentReleases := &models.RecentReleases{
+		CurrentPage: page,
+		HasNextPage: false,
+		Results:     []models.RecentRelease{},
+	}
+
+	c := colly.NewCollector()
+
+	c.OnHTML("ul.pagination-list > li.selected", func(e *colly.HTMLElement) {
+		if e.DOM.Next().Length() > 0 {
+			recentReleases.HasNextPage = true
+		}
+	})
+
+	c.OnHTML("div.last_episodes > ul > li", func(e *colly.HTMLElement) {
+		href := strings.TrimSpace(e.ChildAttr("p.name > a", "href"))
+		episodeID := strings.TrimPrefix(href, "/")
+
+		id := episodeID
+		if i := strings.LastIndex(id, "-episode-"); i > 0 {
+			id = id[:i]
+		}
+
+		result := models.RecentRelease{
+			ID:            id,
+			EpisodeID:     episodeID,
+			EpisodeNumber: parseEpisodeNumber(strings.TrimPrefix(e.ChildText("p.episode"), "Episode ")),
+			Title:         e.ChildText("p.name > a"),
+			URL:           baseURL + href,
+			Image:         e.ChildAttr("div > a > img", "src"),
+			SubOrDub:      DetermineSubOrDub(e.ChildText("p.name > a")),
+		}
+		recentReleases.Results = append(recentReleases.Results, result)
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		fmt.Println("Request URL:", r.Request.URL, "failed with response:", r, "\n", err)
+	})
+
+	url := fmt.Sprintf("%s/page-recent-release.html?page=%d&type=%d", ajaxURL, page, releaseType)
+	err := c.Visit(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return recentReleases, nil
+}
+
+func scrapeListing(ctx context.Context, url string, page int) (*models.SearchResult, error) {
 	searchResult := &models.SearchResult{
 		CurrentPage: page,
 		HasNextPage: false,
 		Results:     []models.AnimeResult{},
 	}
 
-	c := colly.NewCollector()
+	c := newCollector(ctx)
 
-	c.OnHTML("div.anime_name.new_series > div > div > ul > li.selected", func(e *colly.HTMLElement) {
+	c.OnHTML("ul.pagination-list > li.selected", func(e *colly.HTMLElement) {
 		if e.DOM.Next().Length() > 0 {
 			searchResult.HasNextPage = true
 		}
@@ -37,8 +340,8 @@ func Search(query string, page int) (*models.SearchResult, error) {
 			Title:       e.ChildText("p.name > a"),
 			URL:         baseURL + e.ChildAttr("p.name > a", "href"),
 			Image:       e.ChildAttr("div > a > img", "src"),
-			ReleaseDate: strings.Split(e.ChildText("p.released"), "Released: ")[1],
-			SubOrDub:    determineSubOrDub(e.ChildText("p.name > a")),
+			ReleaseDate: strings.TrimSpace(strings.TrimPrefix(e.ChildText("p.released"), "Released:")),
+			SubOrDub:    DetermineSubOrDub(e.ChildText("p.name > a")),
 		}
 		searchResult.Results = append(searchResult.Results, result)
 	})
@@ -47,7 +350,6 @@ func Search(query string, page int) (*models.SearchResult, error) {
 		fmt.Println("Request URL:", r.Request.URL, "failed with response:", r, "\n", err)
 	})
 
-	url := fmt.Sprintf("%s/filter.html?keyword=%s&page=%d", baseURL, query, page)
 	err := c.Visit(url)
 	if err != nil {
 		return nil, err
@@ -56,13 +358,44 @@ func Search(query string, page int) (*models.SearchResult, error) {
 	return searchResult, nil
 }
 
+// newCollector returns a collector whose requests are abandoned once ctx is
+// done. Colly has no way of its own to pass a context to its requests.
+func newCollector(ctx context.Context) *colly.Collector {
+	c := colly.NewCollector()
+	c.WithTransport(contextTransport{ctx: ctx, base: http.DefaultTransport})
+	return c
+}
+
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
 func Info(id string) (*models.AnimeInfo, error) {
+	return InfoStream(context.Background(), id, nil, nil)
+}
+
+// InfoStream is Info for callers that want partial results. onInfo, if not
+// nil, is called with the base info once the category page is parsed, before
+// any episodes are fetched. onEpisodes, if not nil, is called with each batch
+// of episodes, which are then fetched one page of the episode list at a time
+// rather than in one request. It stops between batches once ctx is done.
+func InfoStream(ctx context.Context, id string, onInfo func(*models.AnimeInfo), onEpisodes func([]models.Episode)) (*models.AnimeInfo, error) {
 	result := &models.AnimeInfo{Episodes: []models.Episode{}}
 
 	if !strings.Contains(id, "gogoanime") {
-		id = fmt.Sprintf("%s/category/%s", baseURL, id)
+		id = CategoryURL(id)
 	}
 
+	var (
+		ranges         [][2]string
+		movieID, alias string
+	)
+
 	c := colly.NewCollector()
 
 	c.OnHTML("body", func(e *colly.HTMLElement) {
@@ -81,7 +414,7 @@ func Info(id string) (*models.AnimeInfo, error) {
 		result.Image = e.ChildAttr("div.anime_info_body_bg > img", "src")
 		result.ReleaseDate = strings.TrimSpace(strings.Split(e.ChildText("div.anime_info_body_bg > p:nth-child(8)"), "Released: ")[1])
 		result.Description = strings.TrimPrefix(e.ChildText("div.anime_info_body_bg > div:nth-child(6)"), "Plot Summary: ")
-		result.SubOrDub = determineSubOrDub(result.Title)
+		result.SubOrDub = DetermineSubOrDub(result.Title)
 		result.Type = models.MediaFormat(strings.Split(strings.ToUpper(e.ChildText("div.anime_info_body_bg > p:nth-child(4) > a")), " ")[2])
 
 		status := e.ChildText("div.anime_info_body_bg > p:nth-child(9) > a")
@@ -101,24 +434,45 @@ func Info(id string) (*models.AnimeInfo, error) {
 			result.Genres = append(result.Genres, el.Attr("title"))
 		})
 
-		epStart := e.DOM.Find("#episode_page > li").First().Find("a").AttrOr("ep_start", "")
-		epEnd := e.DOM.Find("#episode_page > li").Last().Find("a").AttrOr("ep_end", "")
-		movieID := e.ChildAttr("#movie_id", "value")
-		alias := e.ChildAttr("#alias_anime", "value")
-
-		episodes, err := FetchEpisode(epStart, epEnd, movieID, alias)
-		if err != nil {
-			return
+		pages := e.DOM.Find("#episode_page > li a")
+		if onEpisodes == nil {
+			ranges = [][2]string{{pages.First().AttrOr("ep_start", ""), pages.Last().AttrOr("ep_end", "")}}
+		} else {
+			pages.Each(func(_ int, a *goquery.Selection) {
+				ranges = append(ranges, [2]string{a.AttrOr("ep_start", ""), a.AttrOr("ep_end", "")})
+			})
 		}
 
-		result.Episodes = episodes
-		result.TotalEpisodes = len(episodes)
+		movieID = e.ChildAttr("#movie_id", "value")
+		alias = e.ChildAttr("#alias_anime", "value")
 	})
 
 	if err := c.Visit(id); err != nil {
 		return nil, err
 	}
 
+	if onInfo != nil {
+		onInfo(result)
+	}
+
+	for _, r := range ranges {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		episodes, err := FetchEpisode(r[0], r[1], movieID, alias)
+		if err != nil {
+			break
+		}
+
+		result.Episodes = append(result.Episodes, episodes...)
+		result.TotalEpisodes = len(result.Episodes)
+
+		if onEpisodes != nil && len(episodes) > 0 {
+			onEpisodes(episodes)
+		}
+	}
+
 	return result, nil
 }
 
@@ -178,6 +532,10 @@ func Downloads(id string) ([]models.EpisodeServer, error) {
 	return servers, nil
 }
 
+func CategoryURL(id string) string {
+	return fmt.Sprintf("%s/category/%s", baseURL, id)
+}
+
 func parseEpisodeNumber(text string) float64 {
 	number := strings.Replace(strings.TrimPrefix(text, "EP "), " ", "", -1)
 	if num, err := strconv.ParseFloat(number, 64); err == nil {
@@ -186,7 +544,7 @@ func parseEpisodeNumber(text string) float64 {
 	return 0
 }
 
-func determineSubOrDub(title string) models.SubOrDub {
+func DetermineSubOrDub(title string) models.SubOrDub {
 	if strings.Contains(strings.ToLower(title), "(dub)") {
 		return models.DUB
 	}
@@ -0,0 +1,101 @@
+package anime
+
+import (
+	"sort"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+const (
+	ScheduleHistory = 28 * 24 * time.Hour
+	scheduleStale   = 14 * 24 * time.Hour
+)
+
+// Schedule derives a weekly airing schedule from previously observed episode
+// releases. Each series is placed on the weekday it has most often been
+// released on, at the time of its most recent release on that weekday.
+// Series which haven't had a release for a couple of weeks are assumed to have
+// finished airing and are left out.
+func Schedule(history []models.ReleaseRecord, loc *time.Location, now time.Time) *models.Schedule {
+	type series struct {
+		latest   models.ReleaseRecord
+		weekdays [7]int
+		lastOn   [7]time.Time
+	}
+
+	seen := map[string]*series{}
+	for _, release := range history {
+		s, ok := seen[release.AnimeID]
+		if !ok {
+			s = &series{latest: release}
+			seen[release.AnimeID] = s
+		}
+
+		if release.ReleasedAt.After(s.latest.ReleasedAt) {
+			s.latest = release
+		}
+
+		local := release.ReleasedAt.In(loc)
+		s.weekdays[local.Weekday()]++
+		if local.After(s.lastOn[local.Weekday()]) {
+			s.lastOn[local.Weekday()] = local
+		}
+	}
+
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	schedule := &models.Schedule{
+		Timezone: loc.String(),
+		Days:     make([]models.ScheduleDay, 7),
+	}
+
+	for i := range schedule.Days {
+		date := today.AddDate(0, 0, i)
+		schedule.Days[i] = models.ScheduleDay{
+			Day:     date.Weekday().String(),
+			Date:    date.Format("2006-01-02"),
+			Entries: []models.ScheduleEntry{},
+		}
+	}
+
+	for id, s := range seen {
+		if now.Sub(s.latest.ReleasedAt) > scheduleStale {
+			continue
+		}
+
+		weekday := s.latest.ReleasedAt.In(loc).Weekday()
+		for wd, count := range s.weekdays {
+			if count > s.weekdays[weekday] {
+				weekday = time.Weekday(wd)
+			}
+		}
+
+		offset := (int(weekday) - int(today.Weekday()) + 7) % 7
+		last := s.lastOn[weekday]
+		date := today.AddDate(0, 0, offset)
+
+		schedule.Days[offset].Entries = append(schedule.Days[offset].Entries, models.ScheduleEntry{
+			ID:            id,
+			Title:         s.latest.Title,
+			Image:         s.latest.Image,
+			SubOrDub:      s.latest.SubOrDub,
+			Time:          last.Format("15:04"),
+			AiringAt:      time.Date(date.Year(), date.Month(), date.Day(), last.Hour(), last.Minute(), 0, 0, loc),
+			LatestEpisode: s.latest.EpisodeNumber,
+			NextEpisode:   s.latest.EpisodeNumber + 1,
+		})
+	}
+
+	for _, day := range schedule.Days {
+		sort.Slice(day.Entries, func(i, j int) bool {
+			if day.Entries[i].Time == day.Entries[j].Time {
+				return day.Entries[i].Title < day.Entries[j].Title
+			}
+			return day.Entries[i].Time < day.Entries[j].Time
+		})
+	}
+
+	return schedule
+}
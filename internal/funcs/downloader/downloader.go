@@ -0,0 +1,206 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+var (
+	ErrUnsupported  = errors.New("no downloader supports this url")
+	ErrAccessDenied = errors.New("file is private or access to it was denied")
+)
+
+// Downloader resolves links on one or more hosts to the files behind them.
+type Downloader interface {
+	Name() string
+	Match(rawURL string) bool
+	Resolve(ctx context.Context, rawURL string) (*models.Result, error)
+}
+
+var (
+	mu          sync.RWMutex
+	downloaders = []Downloader{
+		Mediafire{},
+		Tiktok{},
+		GoogleDrive{},
+		Pixeldrain{},
+		Gofile{},
+		Dropbox{},
+		socialDownloader{name: "instagram", match: IsInstagramURL, resolve: InstagramDownloader},
+		socialDownloader{name: "twitter", match: IsTwitterURL, resolve: TwitterDownloader},
+		socialDownloader{name: "youtube", match: IsYoutubeShortURL, resolve: YoutubeShort},
+	}
+)
+
+// Register adds a downloader to the set consulted by Find, replacing any
+// existing downloader with the same name.
+func Register(d Downloader) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range downloaders {
+		if downloaders[i].Name() == d.Name() {
+			downloaders[i] = d
+			return
+		}
+	}
+
+	downloaders = append(downloaders, d)
+}
+
+func Downloaders() []Downloader {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]Downloader(nil), downloaders...)
+}
+
+// Find returns the first registered downloader which matches rawURL.
+func Find(rawURL string) (Downloader, bool) {
+	for _, d := range Downloaders() {
+		if d.Match(rawURL) {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+func Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	d, ok := Find(rawURL)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	return d.Resolve(ctx, rawURL)
+}
+
+// newCollector returns a collector whose requests are abandoned once ctx is
+// done. Colly has no way of its own to pass a context to its requests.
+func newCollector(ctx context.Context) *colly.Collector {
+	c := colly.NewCollector()
+	c.WithTransport(contextTransport{ctx: ctx, base: http.DefaultTransport})
+	return c
+}
+
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// matchHost reports whether rawURL is on one of hosts or a subdomain of one.
+func matchHost(rawURL string, hosts ...string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mimeTypeOf(filename string) string {
+	if t := mime.TypeByExtension(path.Ext(filename)); t != "" {
+		return t
+	}
+
+	return "application/octet-stream"
+}
+
+type probedFile struct {
+	URL         string
+	Filename    string
+	MimeType    string
+	Size        int64
+	ContentType string
+	Body        []byte
+}
+
+// probeFile requests the first byte of a download to learn its name, type and
+// size without fetching the whole file. HTML responses are read in full since
+// they are usually an interstitial rather than the file itself.
+func probeFile(ctx context.Context, rawURL string) (*probedFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+	case http.StatusNotFound, http.StatusGone:
+		return nil, ErrFileDeleted
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrAccessDenied
+	default:
+		return nil, fmt.Errorf("failed to fetch file, status code: %d", resp.StatusCode)
+	}
+
+	file := &probedFile{
+		URL:         resp.Request.URL.String(),
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(file.ContentType); err == nil {
+		file.MimeType = mediaType
+	}
+
+	if file.MimeType == "text/html" {
+		file.Body, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return file, err
+	}
+
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		file.Filename = params["filename"]
+	}
+
+	// Content-Range is "bytes 0-0/<total>" for a partial response, with * in
+	// place of the total when it isn't known. Content-Length is only the
+	// length of the range then.
+	if resp.StatusCode == http.StatusPartialContent {
+		file.Size = 0
+
+		contentRange := resp.Header.Get("Content-Range")
+		if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+			if size, err := strconv.ParseInt(contentRange[i+1:], 10, 64); err == nil {
+				file.Size = size
+			}
+		}
+	}
+
+	if file.Size < 0 {
+		file.Size = 0
+	}
+
+	return file, nil
+}
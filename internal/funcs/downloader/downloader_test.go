@@ -0,0 +1,36 @@
+package downloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixture returns a recorded upstream response from testdata.
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// stubUpstream starts a server running handler and points upstream at it for
+// the rest of the test.
+func stubUpstream(t *testing.T, upstream *string, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	original := *upstream
+	*upstream = srv.URL
+	t.Cleanup(func() { *upstream = original })
+
+	return srv
+}
@@ -0,0 +1,77 @@
+package downloader
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+type Dropbox struct{}
+
+func (Dropbox) Name() string {
+	return "dropbox"
+}
+
+func (Dropbox) Match(rawURL string) bool {
+	if !matchHost(rawURL, "dropbox.com") {
+		return false
+	}
+
+	u, _ := url.Parse(rawURL)
+	for _, prefix := range []string{"/s/", "/sh/", "/scl/fi/", "/scl/fo/"} {
+		if strings.HasPrefix(u.Path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolve turns a shared link into its direct download by setting dl=1.
+// Shared folders download as a zip of their contents.
+func (Dropbox) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	params := u.Query()
+	params.Set("dl", "1")
+	u.RawQuery = params.Encode()
+
+	file, err := probeFile(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Dropbox answers links which no longer exist with an HTML error page.
+	if file.MimeType == "text/html" {
+		return nil, ErrFileDeleted
+	}
+
+	filename := file.Filename
+	if filename == "" {
+		filename = path.Base(u.Path)
+	}
+
+	mimeType := file.MimeType
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = mimeTypeOf(filename)
+	}
+
+	result := &models.Result{
+		Source: "dropbox",
+		Title:  filename,
+		Files: []models.File{{
+			URL:      u.String(),
+			Filename: filename,
+			MimeType: mimeType,
+			Size:     file.Size,
+		}},
+	}
+
+	return result, nil
+}
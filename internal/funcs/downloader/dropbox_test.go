@@ -0,0 +1,119 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+func TestDropbox(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dl") != "1" {
+			t.Errorf("got dl=%q; want 1", r.URL.Query().Get("dl"))
+		}
+
+		switch r.URL.Path {
+		case "/s/abc123/notes.pdf":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Range", "bytes 0-0/52428")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("%"))
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<!DOCTYPE html><html><body>This item was deleted</body></html>"))
+		}
+	}))
+	defer srv.Close()
+
+	result, err := Dropbox{}.Resolve(context.Background(), srv.URL+"/s/abc123/notes.pdf?dl=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &models.Result{
+		Source: "dropbox",
+		Title:  "notes.pdf",
+		Files: []models.File{{
+			URL:      srv.URL + "/s/abc123/notes.pdf?dl=1",
+			Filename: "notes.pdf",
+			MimeType: "application/pdf",
+			Size:     52428,
+		}},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %+v; want %+v", result, want)
+	}
+
+	_, err = Dropbox{}.Resolve(context.Background(), srv.URL+"/s/gone00/missing.zip?dl=0")
+	if !errors.Is(err, ErrFileDeleted) {
+		t.Errorf("got error %v; want %v", err, ErrFileDeleted)
+	}
+}
+
+func TestProbeFileSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		want    int64
+	}{
+		{
+			name:    "partial content",
+			status:  http.StatusPartialContent,
+			headers: map[string]string{"Content-Range": "bytes 0-0/1048576"},
+			body:    "x",
+			want:    1048576,
+		},
+		{
+			name:    "unknown total",
+			status:  http.StatusPartialContent,
+			headers: map[string]string{"Content-Range": "bytes 0-0/*"},
+			body:    "x",
+			want:    0,
+		},
+		{
+			name:   "range ignored",
+			status: http.StatusOK,
+			body:   "hello world",
+			want:   11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Range"); got != "bytes=0-0" {
+					t.Errorf("got range %q", got)
+				}
+
+				w.Header().Set("Content-Type", "application/zip")
+				w.Header().Set("Content-Disposition", `attachment; filename="archive.zip"`)
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			file, err := probeFile(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if file.Size != tt.want {
+				t.Errorf("got size %d; want %d", file.Size, tt.want)
+			}
+			if file.Filename != "archive.zip" || file.MimeType != "application/zip" {
+				t.Errorf("got filename %q and mime type %q", file.Filename, file.MimeType)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+var googleDriveURL string = "https://drive.google.com"
+
+// Folders aren't matched, since there is no way to list them without an API
+// key.
+var rgxGoogleDriveID = regexp.MustCompile(`/(?:file/d|open|uc)/?([a-zA-Z0-9_-]{10,})?`)
+
+type GoogleDrive struct{}
+
+func (GoogleDrive) Name() string {
+	return "gdrive"
+}
+
+func (GoogleDrive) Match(rawURL string) bool {
+	return matchHost(rawURL, "drive.google.com", "docs.google.com", "drive.usercontent.google.com") && googleDriveID(rawURL) != ""
+}
+
+// Resolve follows the download link of a shared file. Files too large for
+// Google to virus scan come back as an interstitial with a form to confirm the
+// download; its fields make up the direct link.
+func (GoogleDrive) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	id := googleDriveID(rawURL)
+	if id == "" {
+		return nil, ErrUnsupported
+	}
+
+	downloadURL := fmt.Sprintf("%s/uc?export=download&id=%s", googleDriveURL, url.QueryEscape(id))
+
+	file, err := probeFile(ctx, downloadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.MimeType == "text/html" {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(file.Body))
+		if err != nil {
+			return nil, err
+		}
+
+		form := doc.Find("form#download-form")
+		if form.Length() == 0 {
+			if strings.Contains(file.URL, "accounts.google.com") || doc.Find("form#gaia_loginform").Length() > 0 {
+				return nil, ErrAccessDenied
+			}
+			return nil, ErrFileDeleted
+		}
+
+		action, err := url.Parse(form.AttrOr("action", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		params := url.Values{}
+		form.Find("input[type=hidden]").Each(func(_ int, input *goquery.Selection) {
+			params.Set(input.AttrOr("name", ""), input.AttrOr("value", ""))
+		})
+		action.RawQuery = params.Encode()
+
+		file, err = probeFile(ctx, action.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if file.MimeType == "text/html" {
+			return nil, fmt.Errorf("failed to confirm google drive download")
+		}
+	}
+
+	result := &models.Result{
+		Source: "gdrive",
+		Title:  file.Filename,
+		Files: []models.File{{
+			URL:      file.URL,
+			Filename: file.Filename,
+			MimeType: file.MimeType,
+			Size:     file.Size,
+		}},
+	}
+
+	return result, nil
+}
+
+func googleDriveID(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	if id := u.Query().Get("id"); id != "" {
+		return id
+	}
+
+	if match := rgxGoogleDriveID.FindStringSubmatch(u.Path); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
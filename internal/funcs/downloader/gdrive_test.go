@@ -0,0 +1,133 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const testGoogleDriveID = "1AbCdEfGhIjKlMnOpQrStUvWxYz"
+
+func TestGoogleDriveConfirmPage(t *testing.T) {
+	var srv string
+	srv = stubUpstream(t, &googleDriveURL, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/uc":
+			if r.URL.Query().Get("id") != testGoogleDriveID {
+				t.Errorf("got id %q; want %q", r.URL.Query().Get("id"), testGoogleDriveID)
+			}
+
+			// The real form posts to drive.usercontent.google.com.
+			page := bytes.ReplaceAll(fixture(t, "gdrive_confirm.html"), []byte("https://drive.usercontent.google.com"), []byte(srv))
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(page)
+		case "/download":
+			if r.URL.Query().Get("confirm") != "t" || r.URL.Query().Get("uuid") == "" {
+				t.Errorf("download requested without the confirm form fields: %s", r.URL.RawQuery)
+			}
+
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", `attachment; filename="big-archive.zip"`)
+			w.Header().Set("Content-Range", "bytes 0-0/1288490188")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("P"))
+		default:
+			http.NotFound(w, r)
+		}
+	}).URL
+
+	result, err := GoogleDrive{}.Resolve(context.Background(), "https://drive.google.com/file/d/"+testGoogleDriveID+"/view?usp=sharing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Source != "gdrive" || result.Title != "big-archive.zip" {
+		t.Errorf("got source %q and title %q", result.Source, result.Title)
+	}
+
+	if len(result.Files) != 1 {
+		t.Fatalf("got %d files; want 1", len(result.Files))
+	}
+
+	file := result.Files[0]
+	wantURL := srv + "/download?confirm=t&export=download&id=" + testGoogleDriveID + "&uuid=5f0c6f8e-3b9a-4c1d-9e2f-7a8b9c0d1e2f"
+
+	if file.URL != wantURL {
+		t.Errorf("got url %q; want %q", file.URL, wantURL)
+	}
+	if file.Filename != "big-archive.zip" || file.MimeType != "application/zip" || file.Size != 1288490188 {
+		t.Errorf("got %+v", file)
+	}
+}
+
+func TestGoogleDriveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    error
+	}{
+		{
+			name: "login page",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/uc" {
+					http.Redirect(w, r, "/ServiceLogin?continue=%2Fuc", http.StatusFound)
+					return
+				}
+
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.Write(fixture(t, "gdrive_login.html"))
+			},
+			want: ErrAccessDenied,
+		},
+		{
+			name: "deleted file",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write(fixture(t, "gdrive_deleted.html"))
+			},
+			want: ErrFileDeleted,
+		},
+		{
+			name: "error page without a download form",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.Write(fixture(t, "gdrive_deleted.html"))
+			},
+			want: ErrFileDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubUpstream(t, &googleDriveURL, tt.handler)
+
+			_, err := GoogleDrive{}.Resolve(context.Background(), "https://drive.google.com/uc?id="+testGoogleDriveID)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v; want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleDriveFolder(t *testing.T) {
+	stubUpstream(t, &googleDriveURL, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("folder link requested %s", r.URL)
+	})
+
+	for _, rawURL := range []string{
+		"https://drive.google.com/drive/folders/" + testGoogleDriveID + "?usp=sharing",
+		"https://drive.google.com/drive/u/0/folders/" + testGoogleDriveID,
+	} {
+		if (GoogleDrive{}).Match(rawURL) {
+			t.Errorf("%s matched as a file", rawURL)
+		}
+
+		_, err := GoogleDrive{}.Resolve(context.Background(), rawURL)
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("got error %v for %s; want %v", err, rawURL, ErrUnsupported)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+var (
+	gofileAPIURL string = "https://api.gofile.io"
+
+	// GofileWebsiteToken is the static token the gofile.io website sends with
+	// content requests. Gofile rotates it now and then.
+	GofileWebsiteToken = "4fd6sg89d7s6"
+)
+
+var rgxGofile = regexp.MustCompile(`^/d/([a-zA-Z0-9]+)`)
+
+type GofileContent struct {
+	Type     string                   `json:"type"`
+	Name     string                   `json:"name"`
+	Size     int64                    `json:"size"`
+	MimeType string                   `json:"mimetype"`
+	Link     string                   `json:"link"`
+	Children map[string]GofileContent `json:"children"`
+}
+
+type GofileResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type Gofile struct{}
+
+func (Gofile) Name() string {
+	return "gofile"
+}
+
+func (Gofile) Match(rawURL string) bool {
+	return matchHost(rawURL, "gofile.io") && gofileCode(rawURL) != ""
+}
+
+// Resolve lists a Gofile share using a guest account. Gofile only serves the
+// returned links to clients sending that account's token as the accountToken
+// cookie, so it is reported as the result's Token.
+func (Gofile) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	var account struct {
+		Token string `json:"token"`
+	}
+
+	err := gofileRequest(ctx, http.MethodPost, gofileAPIURL+"/accounts", "", &account)
+	if err != nil {
+		return nil, err
+	}
+
+	var content GofileContent
+	endpoint := fmt.Sprintf("%s/contents/%s?wt=%s", gofileAPIURL, url.PathEscape(gofileCode(rawURL)), url.QueryEscape(GofileWebsiteToken))
+
+	err = gofileRequest(ctx, http.MethodGet, endpoint, account.Token, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Result{
+		Source: "gofile",
+		Title:  content.Name,
+		Token:  account.Token,
+		Files:  []models.File{},
+	}
+
+	var walk func(content GofileContent, prefix string)
+	walk = func(content GofileContent, prefix string) {
+		if content.Type == "file" {
+			result.Files = append(result.Files, models.File{
+				URL:      content.Link,
+				Filename: prefix + content.Name,
+				MimeType: content.MimeType,
+				Size:     content.Size,
+			})
+			return
+		}
+
+		ids := make([]string, 0, len(content.Children))
+		for id := range content.Children {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return content.Children[ids[i]].Name < content.Children[ids[j]].Name })
+
+		for _, id := range ids {
+			child := content.Children[id]
+			if child.Type == "folder" {
+				walk(child, prefix+child.Name+"/")
+			} else {
+				walk(child, prefix)
+			}
+		}
+	}
+	walk(content, "")
+
+	return result, nil
+}
+
+func gofileRequest(ctx context.Context, method, endpoint, token string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response GofileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	switch response.Status {
+	case "ok":
+		return json.Unmarshal(response.Data, dst)
+	case "error-notFound":
+		return ErrFileDeleted
+	case "error-passwordRequired", "error-passwordWrong":
+		return ErrPasswordProtected
+	case "error-notPublic", "error-notPremium":
+		return ErrAccessDenied
+	default:
+		return fmt.Errorf("gofile api error: %s", response.Status)
+	}
+}
+
+func gofileCode(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	if match := rgxGofile.FindStringSubmatch(u.Path); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
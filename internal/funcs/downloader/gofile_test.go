@@ -0,0 +1,87 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+const testGofileToken = "gUeStToKeN0123456789abcdefABCDEF"
+
+// stubGofile serves a guest account and answers content requests with the
+// named fixture, checking they are made with that account's token.
+func stubGofile(t *testing.T, contents string) {
+	t.Helper()
+
+	stubUpstream(t, &gofileAPIURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/accounts":
+			w.Write(fixture(t, "gofile_account.json"))
+		case r.Method == http.MethodGet && r.URL.Path == "/contents/AbCdEf":
+			if got := r.Header.Get("Authorization"); got != "Bearer "+testGofileToken {
+				t.Errorf("got authorization %q", got)
+			}
+			if got := r.URL.Query().Get("wt"); got != GofileWebsiteToken {
+				t.Errorf("got website token %q", got)
+			}
+
+			w.Write(fixture(t, contents))
+		default:
+			w.Write([]byte(`{"status":"error-notFound","data":{}}`))
+		}
+	})
+}
+
+func TestGofileNestedFolders(t *testing.T) {
+	stubGofile(t, "gofile_folder.json")
+
+	result, err := Gofile{}.Resolve(context.Background(), "https://gofile.io/d/AbCdEf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &models.Result{
+		Source: "gofile",
+		Title:  "Season 1",
+		Token:  testGofileToken,
+		Files: []models.File{
+			{
+				URL:      "https://store4.gofile.io/download/web/a1b2c3d4-0000-4000-8000-000000000006/ncop.mp4",
+				Filename: "Extras/Creditless/ncop.mp4",
+				MimeType: "video/mp4",
+				Size:     89128960,
+			},
+			{
+				URL:      "https://store4.gofile.io/download/web/a1b2c3d4-0000-4000-8000-000000000004/episode-01.mp4",
+				Filename: "episode-01.mp4",
+				MimeType: "video/mp4",
+				Size:     734003200,
+			},
+			{
+				URL:      "https://store4.gofile.io/download/web/a1b2c3d4-0000-4000-8000-000000000002/episode-02.mp4",
+				Filename: "episode-02.mp4",
+				MimeType: "video/mp4",
+				Size:     712507392,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %+v; want %+v", result, want)
+	}
+}
+
+func TestGofilePasswordProtected(t *testing.T) {
+	stubGofile(t, "gofile_password.json")
+
+	_, err := Gofile{}.Resolve(context.Background(), "https://gofile.io/d/AbCdEf")
+	if !errors.Is(err, ErrPasswordProtected) {
+		t.Errorf("got error %v; want %v", err, ErrPasswordProtected)
+	}
+}
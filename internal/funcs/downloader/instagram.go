@@ -0,0 +1,178 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+// InstagramURL is where post pages are fetched from. Their JSON form is
+// served when ?__a=1 is added and the web app's ID is sent along.
+var InstagramURL = "https://www.instagram.com"
+
+const instagramAppID = "936619743392459"
+
+var rgxInstagramPost = regexp.MustCompile(`^/(?:[A-Za-z0-9_.]+/)?(p|reels?|tv)/([A-Za-z0-9_-]+)`)
+
+type InstagramMedia struct {
+	MediaType      int `json:"media_type"`
+	ImageVersions2 struct {
+		Candidates []InstagramVersion `json:"candidates"`
+	} `json:"image_versions2"`
+	VideoVersions []InstagramVersion `json:"video_versions"`
+}
+
+type InstagramVersion struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type InstagramResponse struct {
+	Items []struct {
+		InstagramMedia
+		Code    string `json:"code"`
+		TakenAt int64  `json:"taken_at"`
+		Caption *struct {
+			Text string `json:"text"`
+		} `json:"caption"`
+		LikeCount    int64 `json:"like_count"`
+		CommentCount int64 `json:"comment_count"`
+		PlayCount    int64 `json:"play_count"`
+		User         struct {
+			Username      string `json:"username"`
+			FullName      string `json:"full_name"`
+			ProfilePicURL string `json:"profile_pic_url"`
+		} `json:"user"`
+		CarouselMedia []InstagramMedia `json:"carousel_media"`
+	} `json:"items"`
+}
+
+func IsInstagramURL(rawURL string) bool {
+	return matchHost(rawURL, "instagram.com") && instagramShortcode(rawURL) != ""
+}
+
+// InstagramDownloader looks up a public post, reel or carousel. Carousels
+// return every image and every video in them.
+func InstagramDownloader(ctx context.Context, postURL string) (*models.SocialPost, error) {
+	shortcode := instagramShortcode(postURL)
+	if shortcode == "" {
+		return nil, ErrUnsupported
+	}
+
+	endpoint := fmt.Sprintf("%s/p/%s/?__a=1&__d=dis", InstagramURL, url.PathEscape(shortcode))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-IG-App-ID", instagramAppID)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrFileDeleted
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrAccessDenied
+	default:
+		return nil, fmt.Errorf("failed to fetch post, status code: %d", resp.StatusCode)
+	}
+
+	var response InstagramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		// Private posts and rate limits redirect to the HTML login page.
+		return nil, ErrAccessDenied
+	}
+
+	if len(response.Items) == 0 {
+		return nil, ErrFileDeleted
+	}
+
+	item := response.Items[0]
+	post := &models.SocialPost{
+		ID:       item.Code,
+		Platform: "instagram",
+		URL:      fmt.Sprintf("https://www.instagram.com/p/%s/", item.Code),
+		Author: models.SocialAuthor{
+			Username: "@" + item.User.Username,
+			Nickname: item.User.FullName,
+			Avatar:   item.User.ProfilePicURL,
+			URL:      "https://www.instagram.com/" + item.User.Username + "/",
+		},
+		Hashtags:  []string{},
+		Liked:     item.LikeCount,
+		Commented: item.CommentCount,
+		Played:    item.PlayCount,
+		Videos:    []models.MediaVariant{},
+		Images:    []models.MediaVariant{},
+	}
+
+	if item.Caption != nil {
+		post.Caption = item.Caption.Text
+		post.Hashtags = Hashtags(item.Caption.Text)
+	}
+
+	if item.TakenAt > 0 {
+		post.CreatedAt = time.Unix(item.TakenAt, 0).UTC()
+	}
+
+	media := item.CarouselMedia
+	if len(media) == 0 {
+		media = []InstagramMedia{item.InstagramMedia}
+	}
+
+	for _, m := range media {
+		// Candidates come largest first; the rest are downscaled copies.
+		if len(m.VideoVersions) > 0 {
+			for _, version := range m.VideoVersions {
+				post.Videos = append(post.Videos, models.MediaVariant{
+					URL:      version.URL,
+					MimeType: "video/mp4",
+					Quality:  qualityOf(version.Width, version.Height),
+					Width:    version.Width,
+					Height:   version.Height,
+				})
+			}
+		} else if len(m.ImageVersions2.Candidates) > 0 {
+			best := m.ImageVersions2.Candidates[0]
+			post.Images = append(post.Images, models.MediaVariant{
+				URL:      best.URL,
+				MimeType: "image/jpeg",
+				Quality:  qualityOf(best.Width, best.Height),
+				Width:    best.Width,
+				Height:   best.Height,
+			})
+		}
+
+		if post.Thumbnail == "" && len(m.ImageVersions2.Candidates) > 0 {
+			post.Thumbnail = m.ImageVersions2.Candidates[0].URL
+		}
+	}
+
+	return post, nil
+}
+
+func instagramShortcode(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	if match := rgxInstagramPost.FindStringSubmatch(u.Path); match != nil {
+		return match[2]
+	}
+
+	return ""
+}
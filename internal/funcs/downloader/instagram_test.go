@@ -0,0 +1,135 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+func stubInstagram(t *testing.T) {
+	t.Helper()
+
+	stubUpstream(t, &InstagramURL, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-IG-App-ID"); got != instagramAppID {
+			t.Errorf("got app id %q", got)
+		}
+		if r.URL.Query().Get("__a") != "1" {
+			t.Errorf("post requested without __a=1: %s", r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/p/C6aBcDeFgHi/":
+			w.Write(fixture(t, "instagram_post.json"))
+		case "/p/C6jKlMnOpQr/":
+			w.Write(fixture(t, "instagram_carousel.json"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+var testInstagramAuthor = models.SocialAuthor{
+	Username: "@example.photos",
+	Nickname: "Example Photos",
+	Avatar:   "https://scontent.cdninstagram.com/v/t51.2885-19/avatar.jpg",
+	URL:      "https://www.instagram.com/example.photos/",
+}
+
+func TestInstagramPost(t *testing.T) {
+	stubInstagram(t)
+
+	post, err := InstagramDownloader(context.Background(), "https://www.instagram.com/p/C6aBcDeFgHi/?igsh=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image := "https://scontent.cdninstagram.com/v/t51.29350-15/post_1080.jpg"
+	want := &models.SocialPost{
+		ID:        "C6aBcDeFgHi",
+		Platform:  "instagram",
+		URL:       "https://www.instagram.com/p/C6aBcDeFgHi/",
+		Author:    testInstagramAuthor,
+		Caption:   "Sunset at the pier #travel #Sunset #travel",
+		Hashtags:  []string{"travel", "sunset"},
+		Thumbnail: image,
+		CreatedAt: time.Unix(1714560000, 0).UTC(),
+		Liked:     1520,
+		Commented: 34,
+		Videos:    []models.MediaVariant{},
+		Images: []models.MediaVariant{
+			{URL: image, MimeType: "image/jpeg", Quality: "1080p", Width: 1080, Height: 1350},
+		},
+	}
+
+	if !reflect.DeepEqual(post, want) {
+		t.Errorf("got %+v; want %+v", post, want)
+	}
+
+	result := socialResult(post)
+	wantResult := &models.Result{
+		Source:    "instagram",
+		Title:     "Sunset at the pier #travel #Sunset #travel",
+		Author:    "@example.photos",
+		Thumbnail: image,
+		Files:     []models.File{{URL: image, MimeType: "image/jpeg", Quality: "1080p"}},
+	}
+
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("got %+v; want %+v", result, wantResult)
+	}
+}
+
+func TestInstagramCarousel(t *testing.T) {
+	stubInstagram(t)
+
+	post, err := InstagramDownloader(context.Background(), "https://www.instagram.com/example.photos/p/C6jKlMnOpQr/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slide1 := "https://scontent.cdninstagram.com/v/t51.29350-15/slide1_1080.jpg"
+	video720 := "https://scontent.cdninstagram.com/o1/v/t16/slide2_720.mp4"
+	video480 := "https://scontent.cdninstagram.com/o1/v/t16/slide2_480.mp4"
+
+	want := &models.SocialPost{
+		ID:        "C6jKlMnOpQr",
+		Platform:  "instagram",
+		URL:       "https://www.instagram.com/p/C6jKlMnOpQr/",
+		Author:    testInstagramAuthor,
+		Caption:   "Weekend dump",
+		Hashtags:  []string{},
+		Thumbnail: slide1,
+		CreatedAt: time.Unix(1714646400, 0).UTC(),
+		Liked:     870,
+		Commented: 12,
+		Videos: []models.MediaVariant{
+			{URL: video720, MimeType: "video/mp4", Quality: "720p", Width: 720, Height: 1280},
+			{URL: video480, MimeType: "video/mp4", Quality: "480p", Width: 480, Height: 854},
+		},
+		Images: []models.MediaVariant{
+			{URL: slide1, MimeType: "image/jpeg", Quality: "1080p", Width: 1080, Height: 1080},
+		},
+	}
+
+	if !reflect.DeepEqual(post, want) {
+		t.Errorf("got %+v; want %+v", post, want)
+	}
+
+	// Videos come before images, each in the order the platform ranks them.
+	result := socialResult(post)
+	wantFiles := []models.File{
+		{URL: video720, MimeType: "video/mp4", Quality: "720p"},
+		{URL: video480, MimeType: "video/mp4", Quality: "480p"},
+		{URL: slide1, MimeType: "image/jpeg", Quality: "1080p"},
+	}
+
+	if !reflect.DeepEqual(result.Files, wantFiles) {
+		t.Errorf("got files %+v; want %+v", result.Files, wantFiles)
+	}
+}
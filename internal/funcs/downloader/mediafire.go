@@ -1,29 +1,113 @@
 package downloader
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gocolly/colly/v2"
 
 	models "miruchigawa.moe/restapi/internal/models/downloader"
 )
 
-func GetMediafireInfo(url string) (*models.MediafireInfo, error) {
+var mediafireAPIURL string = "https://www.mediafire.com/api/1.5"
+
+var MediafireHosts = []string{"mediafire.com", "www.mediafire.com", "app.mediafire.com"}
+
+var (
+	ErrPasswordProtected = errors.New("file is password protected")
+	ErrFileDeleted       = errors.New("file has been deleted or does not exist")
+)
+
+const (
+	mediafireMaxDepth   = 5
+	mediafireMaxFiles   = 500
+	mediafireTimeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	rgxMediafireExt    = regexp.MustCompile(`\(\.(.*?)\)`)
+	rgxMediafireFolder = regexp.MustCompile(`^/folder/([a-zA-Z0-9]+)`)
+	rgxMediafireSize   = regexp.MustCompile(`(?i)^([\d.,]+)\s*([KMGT]?B)$`)
+)
+
+func IsMediafireURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range MediafireHosts {
+		if host == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MediafireFolderKey returns the folder key of a folder URL, or "" for
+// anything else.
+func MediafireFolderKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	if match := rgxMediafireFolder.FindStringSubmatch(u.Path); match != nil {
+		return match[1]
+	}
+
+	// Older share links put the key in the fragment, e.g. /#myfolderkey.
+	if u.Path == "" || u.Path == "/" {
+		return u.Fragment
+	}
+
+	return ""
+}
+
+func GetMediafireInfo(ctx context.Context, url string) (*models.MediafireInfo, error) {
 	info := &models.MediafireInfo{}
-	c := colly.NewCollector()
+	var protected, deleted bool
+	c := newCollector(ctx)
+
+	c.OnResponse(func(r *colly.Response) {
+		// Deleted and invalid files redirect to an error page.
+		if strings.Contains(r.Request.URL.Path, "error.php") {
+			deleted = true
+		}
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		if r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusGone {
+			deleted = true
+		}
+	})
 
 	c.OnHTML("body", func(e *colly.HTMLElement) {
 		doc := e.DOM
 
+		if doc.Find("form[name=form_password], #form_password, .passwordPrompt").Length() > 0 {
+			protected = true
+			return
+		}
+
 		info.URL = strings.TrimSpace(doc.Find("#downloadButton").AttrOr("href", ""))
 
 		intro := doc.Find("div.dl-info > div.intro")
 		info.Filename = strings.TrimSpace(intro.Find("div.filename").Text())
 		info.Filetype = strings.TrimSpace(intro.Find("div.filetype > span").Eq(0).Text())
 
-		re := regexp.MustCompile(`\(\.(.*?)\)`)
-		match := re.FindStringSubmatch(strings.TrimSpace(intro.Find("div.filetype > span").Eq(1).Text()))
+		match := rgxMediafireExt.FindStringSubmatch(strings.TrimSpace(intro.Find("div.filetype > span").Eq(1).Text()))
 		if len(match) > 1 {
 			info.Ext = strings.TrimSpace(match[1])
 		} else {
@@ -31,14 +115,327 @@ func GetMediafireInfo(url string) (*models.MediafireInfo, error) {
 		}
 
 		li := doc.Find("div.dl-info > ul.details > li")
-		info.Uploaded = strings.TrimSpace(li.Eq(1).Find("span").Text())
-		info.Filesize = strings.TrimSpace(li.Eq(0).Find("span").Text())
+		info.Uploaded = parseMediafireTime(strings.TrimSpace(li.Eq(1).Find("span").Text()))
+		info.Filesize = ParseSize(strings.TrimSpace(li.Eq(0).Find("span").Text()))
 	})
 
 	err := c.Visit(url)
-	if err != nil {
+	if err != nil && !deleted {
 		return nil, err
 	}
 
+	switch {
+	case protected:
+		return nil, ErrPasswordProtected
+	case deleted, info.URL == "":
+		return nil, ErrFileDeleted
+	}
+
 	return info, nil
 }
+
+type MediafireFolderResponse struct {
+	Response struct {
+		Result     string `json:"result"`
+		Message    string `json:"message"`
+		Error      int    `json:"error"`
+		FolderInfo *struct {
+			FolderKey string `json:"folderkey"`
+			Name      string `json:"name"`
+			Created   string `json:"created"`
+		} `json:"folder_info"`
+		FolderContent *struct {
+			MoreChunks string `json:"more_chunks"`
+			Files      []struct {
+				QuickKey string `json:"quickkey"`
+				Filename string `json:"filename"`
+				Size     string `json:"size"`
+				MimeType string `json:"mimetype"`
+				Created  string `json:"created"`
+				Links    struct {
+					NormalDownload string `json:"normal_download"`
+				} `json:"links"`
+			} `json:"files"`
+			Folders []struct {
+				FolderKey string `json:"folderkey"`
+				Name      string `json:"name"`
+				Created   string `json:"created"`
+			} `json:"folders"`
+		} `json:"folder_content"`
+	} `json:"response"`
+}
+
+// GetMediafireFolder lists a folder and its subfolders through the MediaFire
+// API, then resolves the direct download link of every file in it.
+func GetMediafireFolder(ctx context.Context, folderURL string) (*models.MediafireFolder, error) {
+	key := MediafireFolderKey(folderURL)
+	if key == "" {
+		return nil, ErrFileDeleted
+	}
+
+	var response MediafireFolderResponse
+	err := mediafireAPI(ctx, "folder/get_info.php", url.Values{"folder_key": {key}}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Response.FolderInfo == nil {
+		return nil, ErrFileDeleted
+	}
+
+	folder := &models.MediafireFolder{
+		Key:     response.Response.FolderInfo.FolderKey,
+		Name:    response.Response.FolderInfo.Name,
+		Created: parseMediafireTime(response.Response.FolderInfo.Created),
+	}
+
+	var files []*models.MediafireFile
+	err = listMediafireFolder(ctx, folder, 0, &files)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediafireFiles(ctx, files)
+
+	return folder, nil
+}
+
+// listMediafireFolder lists folder and its subfolders into files, stopping
+// once it holds mediafireMaxFiles.
+func listMediafireFolder(ctx context.Context, folder *models.MediafireFolder, depth int, files *[]*models.MediafireFile) error {
+	folder.Files = []models.MediafireFile{}
+	folder.Folders = []models.MediafireFolder{}
+
+	if len(*files) >= mediafireMaxFiles {
+		return nil
+	}
+
+	for _, contentType := range []string{"files", "folders"} {
+		for chunk := 1; ; chunk++ {
+			params := url.Values{
+				"folder_key":   {folder.Key},
+				"content_type": {contentType},
+				"chunk":        {strconv.Itoa(chunk)},
+			}
+
+			var response MediafireFolderResponse
+			err := mediafireAPI(ctx, "folder/get_content.php", params, &response)
+			if err != nil {
+				return err
+			}
+
+			content := response.Response.FolderContent
+			if content == nil {
+				break
+			}
+
+			for _, f := range content.Files {
+				size, _ := strconv.ParseInt(f.Size, 10, 64)
+				folder.Files = append(folder.Files, models.MediafireFile{
+					Key:      f.QuickKey,
+					Filename: f.Filename,
+					MimeType: f.MimeType,
+					Filesize: size,
+					Uploaded: parseMediafireTime(f.Created),
+					Page:     f.Links.NormalDownload,
+				})
+			}
+
+			for _, f := range content.Folders {
+				folder.Folders = append(folder.Folders, models.MediafireFolder{
+					Key:     f.FolderKey,
+					Name:    f.Name,
+					Created: parseMediafireTime(f.Created),
+				})
+			}
+
+			if content.MoreChunks != "yes" || len(*files)+len(folder.Files) >= mediafireMaxFiles {
+				break
+			}
+		}
+	}
+
+	if remaining := mediafireMaxFiles - len(*files); len(folder.Files) > remaining {
+		folder.Files = folder.Files[:remaining]
+	}
+
+	for i := range folder.Files {
+		*files = append(*files, &folder.Files[i])
+	}
+
+	if depth+1 >= mediafireMaxDepth {
+		return nil
+	}
+
+	for i := range folder.Folders {
+		err := listMediafireFolder(ctx, &folder.Folders[i], depth+1, files)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveMediafireFiles fills in the direct download URL of each file from its
+// download page, a few at a time. Files which can't be resolved keep only
+// their page URL.
+func resolveMediafireFiles(ctx context.Context, files []*models.MediafireFile) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+
+	for _, file := range files {
+		if file.Page == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(file *models.MediafireFile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			info, err := GetMediafireInfo(ctx, file.Page)
+			if err == nil {
+				file.URL = info.URL
+			}
+		}(file)
+	}
+
+	wg.Wait()
+}
+
+func mediafireAPI(ctx context.Context, endpoint string, params url.Values, dst *MediafireFolderResponse) error {
+	params.Set("response_format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s?%s", mediafireAPIURL, endpoint, params.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(dst)
+	if err != nil {
+		return err
+	}
+
+	if dst.Response.Result != "Success" {
+		switch dst.Response.Error {
+		// 112 is an invalid or deleted folder key, 114 a folder the owner
+		// has made private.
+		case 112, 114:
+			return ErrFileDeleted
+		default:
+			return fmt.Errorf("mediafire api error %d: %s", dst.Response.Error, dst.Response.Message)
+		}
+	}
+
+	return nil
+}
+
+// ParseSize turns display sizes such as "12.5 MB" into bytes.
+func ParseSize(s string) int64 {
+	match := rgxMediafireSize.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+
+	switch strings.ToUpper(match[2]) {
+	case "KB":
+		value *= 1 << 10
+	case "MB":
+		value *= 1 << 20
+	case "GB":
+		value *= 1 << 30
+	case "TB":
+		value *= 1 << 40
+	}
+
+	return int64(value + 0.5)
+}
+
+func parseMediafireTime(s string) time.Time {
+	t, err := time.Parse(mediafireTimeLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t.UTC()
+}
+
+type Mediafire struct{}
+
+func (Mediafire) Name() string {
+	return "mediafire"
+}
+
+func (Mediafire) Match(rawURL string) bool {
+	return IsMediafireURL(rawURL)
+}
+
+func (Mediafire) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	if MediafireFolderKey(rawURL) == "" {
+		info, err := GetMediafireInfo(ctx, rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &models.Result{
+			Source: "mediafire",
+			Title:  info.Filename,
+			Files: []models.File{{
+				URL:      info.URL,
+				Filename: info.Filename,
+				MimeType: mimeTypeOf(info.Filename),
+				Size:     info.Filesize,
+			}},
+		}
+
+		return result, nil
+	}
+
+	folder, err := GetMediafireFolder(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Result{
+		Source: "mediafire",
+		Title:  folder.Name,
+		Files:  []models.File{},
+	}
+
+	var walk func(folder models.MediafireFolder, prefix string)
+	walk = func(folder models.MediafireFolder, prefix string) {
+		for _, file := range folder.Files {
+			link := file.URL
+			if link == "" {
+				link = file.Page
+			}
+
+			result.Files = append(result.Files, models.File{
+				URL:      link,
+				Filename: prefix + file.Filename,
+				MimeType: file.MimeType,
+				Size:     file.Filesize,
+			})
+		}
+
+		for _, sub := range folder.Folders {
+			walk(sub, prefix+sub.Name+"/")
+		}
+	}
+	walk(*folder, "")
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMediafireFolderFileCap(t *testing.T) {
+	const perChunk = 300
+
+	stubUpstream(t, &mediafireAPIURL, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var body any
+		switch r.URL.Path {
+		case "/folder/get_info.php":
+			body = map[string]any{"response": map[string]any{
+				"result":      "Success",
+				"folder_info": map[string]any{"folderkey": query.Get("folder_key"), "name": "Scans"},
+			}}
+		case "/folder/get_content.php":
+			content := map[string]any{"more_chunks": "yes", "files": []any{}, "folders": []any{}}
+
+			if query.Get("content_type") == "files" {
+				files := make([]any, perChunk)
+				for i := range files {
+					files[i] = map[string]any{"quickkey": fmt.Sprintf("%s-%d", query.Get("chunk"), i), "filename": "page.jpg", "size": "1024"}
+				}
+				content["files"] = files
+			} else {
+				content["more_chunks"] = "no"
+			}
+
+			body = map[string]any{"response": map[string]any{"result": "Success", "folder_content": content}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(body)
+	})
+
+	folder, err := GetMediafireFolder(context.Background(), "https://www.mediafire.com/folder/abc123def/Scans")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(folder.Files) != mediafireMaxFiles {
+		t.Errorf("got %d files; want %d", len(folder.Files), mediafireMaxFiles)
+	}
+}
+
+func TestMediafireFolderCanceled(t *testing.T) {
+	stubUpstream(t, &mediafireAPIURL, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("requested %s after the context was canceled", r.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetMediafireFolder(ctx, "https://www.mediafire.com/folder/abc123def/Scans")
+	if err == nil {
+		t.Error("got no error")
+	}
+}
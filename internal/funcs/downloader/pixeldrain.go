@@ -0,0 +1,107 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+var pixeldrainURL string = "https://pixeldrain.com"
+
+var rgxPixeldrain = regexp.MustCompile(`^/(u|l)/([a-zA-Z0-9]+)`)
+
+type PixeldrainFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+}
+
+type PixeldrainResponse struct {
+	PixeldrainFile
+	Success *bool            `json:"success"`
+	Value   string           `json:"value"`
+	Title   string           `json:"title"`
+	Files   []PixeldrainFile `json:"files"`
+}
+
+type Pixeldrain struct{}
+
+func (Pixeldrain) Name() string {
+	return "pixeldrain"
+}
+
+func (Pixeldrain) Match(rawURL string) bool {
+	return matchHost(rawURL, "pixeldrain.com") && pixeldrainPath(rawURL) != nil
+}
+
+// Resolve looks up a shared file (/u/) or list (/l/) through the Pixeldrain
+// API.
+func (Pixeldrain) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	match := pixeldrainPath(rawURL)
+
+	endpoint := fmt.Sprintf("%s/api/file/%s/info", pixeldrainURL, url.PathEscape(match[2]))
+	if match[1] == "l" {
+		endpoint = fmt.Sprintf("%s/api/list/%s", pixeldrainURL, url.PathEscape(match[2]))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response PixeldrainResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound || (response.Success != nil && !*response.Success) {
+		if response.Value == "unauthorized" || response.Value == "forbidden" {
+			return nil, ErrAccessDenied
+		}
+		return nil, ErrFileDeleted
+	}
+
+	result := &models.Result{
+		Source: "pixeldrain",
+		Title:  response.Title,
+		Files:  []models.File{},
+	}
+
+	files := response.Files
+	if match[1] == "u" {
+		files = []PixeldrainFile{response.PixeldrainFile}
+		result.Title = response.Name
+	}
+
+	for _, file := range files {
+		result.Files = append(result.Files, models.File{
+			URL:      fmt.Sprintf("%s/api/file/%s?download", pixeldrainURL, url.PathEscape(file.ID)),
+			Filename: file.Name,
+			MimeType: file.MimeType,
+			Size:     file.Size,
+		})
+	}
+
+	return result, nil
+}
+
+func pixeldrainPath(rawURL string) []string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	return rgxPixeldrain.FindStringSubmatch(u.Path)
+}
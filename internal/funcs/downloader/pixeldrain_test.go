@@ -0,0 +1,67 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+func TestPixeldrain(t *testing.T) {
+	srv := stubUpstream(t, &pixeldrainURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/file/AbCd1234/info":
+			w.Write(fixture(t, "pixeldrain_file.json"))
+		case "/api/list/LsT12345":
+			w.Write(fixture(t, "pixeldrain_list.json"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(fixture(t, "pixeldrain_not_found.json"))
+		}
+	})
+
+	episode1 := models.File{URL: srv.URL + "/api/file/AbCd1234?download", Filename: "episode-01.mp4", MimeType: "video/mp4", Size: 734003200}
+	episode2 := models.File{URL: srv.URL + "/api/file/EfGh5678?download", Filename: "episode-02.mp4", MimeType: "video/mp4", Size: 712507392}
+
+	tests := []struct {
+		name string
+		url  string
+		want *models.Result
+	}{
+		{
+			name: "file",
+			url:  "https://pixeldrain.com/u/AbCd1234",
+			want: &models.Result{Source: "pixeldrain", Title: "episode-01.mp4", Files: []models.File{episode1}},
+		},
+		{
+			name: "list",
+			url:  "https://pixeldrain.com/l/LsT12345#item=1",
+			want: &models.Result{Source: "pixeldrain", Title: "Season 1", Files: []models.File{episode1, episode2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Pixeldrain{}.Resolve(context.Background(), tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("got %+v; want %+v", result, tt.want)
+			}
+		})
+	}
+
+	t.Run("deleted file", func(t *testing.T) {
+		_, err := Pixeldrain{}.Resolve(context.Background(), "https://pixeldrain.com/u/GoNe0000")
+		if !errors.Is(err, ErrFileDeleted) {
+			t.Errorf("got error %v; want %v", err, ErrFileDeleted)
+		}
+	})
+}
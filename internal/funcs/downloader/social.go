@@ -0,0 +1,65 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+// socialResult flattens a social post into the common downloader result, best
+// quality video first.
+func socialResult(post *models.SocialPost) *models.Result {
+	result := &models.Result{
+		Source:    post.Platform,
+		Title:     post.Caption,
+		Author:    post.Author.Username,
+		Thumbnail: post.Thumbnail,
+		Files:     []models.File{},
+	}
+
+	for _, variants := range [][]models.MediaVariant{post.Videos, post.Images} {
+		for _, variant := range variants {
+			result.Files = append(result.Files, models.File{
+				URL:      variant.URL,
+				MimeType: variant.MimeType,
+				Quality:  variant.Quality,
+			})
+		}
+	}
+
+	return result
+}
+
+func qualityOf(width, height int) string {
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%dp", min(width, height))
+}
+
+// socialDownloader adapts a function returning a social post to the
+// Downloader interface.
+type socialDownloader struct {
+	name    string
+	match   func(rawURL string) bool
+	resolve func(ctx context.Context, rawURL string) (*models.SocialPost, error)
+}
+
+func (d socialDownloader) Name() string {
+	return d.name
+}
+
+func (d socialDownloader) Match(rawURL string) bool {
+	return d.match(rawURL)
+}
+
+func (d socialDownloader) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	post, err := d.resolve(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return socialResult(post), nil
+}
@@ -1,13 +1,72 @@
 package downloader
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gocolly/colly/v2"
 
 	models "miruchigawa.moe/restapi/internal/models/downloader"
 )
 
-func TiktokDownloader(url string) (*models.TiktokResult, error) {
-	result := &models.TiktokResult{}
+var (
+	ttsaveURL string = "https://ttsave.app/download"
+	tikwmURL  string = "https://www.tikwm.com/api/"
+)
+
+// tiktokClient only follows redirects within tiktok.com, which is all a short
+// link should ever lead to.
+var tiktokClient = &http.Client{
+	Timeout: 10 * time.Second,
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 || !matchHost(req.URL.String(), "tiktok.com") {
+			return http.ErrUseLastResponse
+		}
+
+		return nil
+	},
+}
+
+var (
+	rgxTiktokID = regexp.MustCompile(`/(?:video|photo)/(\d+)`)
+	rgxHashtag  = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+	rgxCount    = regexp.MustCompile(`(?i)^([\d.,]+)\s*([kmb]?)$`)
+)
+
+// TiktokDownloader scrapes a TikTok post through ttsave, falling back to the
+// tikwm API when ttsave doesn't give us anything to download.
+func TiktokDownloader(ctx context.Context, postURL string) (*models.TiktokResult, error) {
+	result, err := ttsaveDownloader(ctx, postURL)
+	if err == nil && (result.Video != "" || len(result.Images) > 0) {
+		return result, nil
+	}
+
+	fallback, fallbackErr := tikwmDownloader(ctx, postURL)
+	if fallbackErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fallbackErr
+	}
+
+	return fallback, nil
+}
+
+func ttsaveDownloader(ctx context.Context, postURL string) (*models.TiktokResult, error) {
+	result := &models.TiktokResult{
+		Type:     models.TIKTOK_VIDEO,
+		Provider: "ttsave",
+		Hashtags: []string{},
+		Images:   []string{},
+	}
 	c := colly.NewCollector()
 
 	c.OnHTML("div.flex h2", func(e *colly.HTMLElement) {
@@ -30,38 +89,279 @@ func TiktokDownloader(url string) (*models.TiktokResult, error) {
 		index := e.Index
 		switch index {
 		case 0:
-			result.Played = e.Text
+			result.Played = ParseCount(e.Text)
 		case 1:
-			result.Commented = e.Text
+			result.Commented = ParseCount(e.Text)
 		case 2:
-			result.Saved = e.Text
+			result.Saved = ParseCount(e.Text)
 		case 3:
-			result.Shared = e.Text
+			result.Shared = ParseCount(e.Text)
 		case 4:
 			result.Song = e.Text
 		}
 	})
 
+	// Links are told apart by their type attribute where ttsave sets one and
+	// by their label otherwise, since the set and order of links differ
+	// between videos and slideshows.
 	c.OnHTML("#button-download-ready a", func(e *colly.HTMLElement) {
 		link := e.Attr("href")
+		if link == "" {
+			return
+		}
 
-		switch e.Index {
-		case 0:
-			result.Video = link
-		case 2:
+		kind := strings.ToLower(e.Attr("type"))
+		label := strings.ToUpper(strings.Join(strings.Fields(e.Text), " "))
+
+		switch {
+		case kind == "slide" || strings.Contains(label, "IMAGE") || strings.Contains(label, "PHOTO"):
+			result.Images = append(result.Images, link)
+		case kind == "audio" || strings.Contains(label, "MP3") || strings.Contains(label, "AUDIO"):
 			result.Audio = link
-		case 4:
+		case kind == "cover" || strings.Contains(label, "COVER"):
 			result.Thumbnail = link
+		case kind == "profile" || strings.Contains(label, "PROFILE") || strings.Contains(label, "AVATAR"):
+		case strings.Contains(label, "HD"):
+			result.VideoHD = link
+		case kind == "watermark" || (strings.Contains(label, "WITH WATERMARK") && !strings.Contains(label, "WITHOUT")):
+			result.VideoWatermark = link
+		case kind == "no-watermark" || strings.Contains(label, "WITHOUT WATERMARK") || result.Video == "":
+			result.Video = link
 		}
 	})
 
-	err := c.Post("https://ttsave.app/download", map[string]string{
+	err := c.Post(ttsaveURL, map[string]string{
 		"language_id": "1",
-		"query":       url,
+		"query":       postURL,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(result.Images) > 0 {
+		result.Type = models.TIKTOK_SLIDESHOW
+	}
+
+	result.ID = tiktokID(ctx, postURL)
+	result.CreatedAt = tiktokCreatedAt(result.ID)
+	result.Hashtags = Hashtags(result.Description)
+
+	return result, nil
+}
+
+type TikwmResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data struct {
+		ID           string   `json:"id"`
+		Title        string   `json:"title"`
+		Cover        string   `json:"cover"`
+		Play         string   `json:"play"`
+		WmPlay       string   `json:"wmplay"`
+		HdPlay       string   `json:"hdplay"`
+		Music        string   `json:"music"`
+		PlayCount    int64    `json:"play_count"`
+		CommentCount int64    `json:"comment_count"`
+		ShareCount   int64    `json:"share_count"`
+		CollectCount int64    `json:"collect_count"`
+		CreateTime   int64    `json:"create_time"`
+		Images       []string `json:"images"`
+		MusicInfo    struct {
+			Title  string `json:"title"`
+			Author string `json:"author"`
+		} `json:"music_info"`
+		Author struct {
+			UniqueID string `json:"unique_id"`
+			Nickname string `json:"nickname"`
+			Avatar   string `json:"avatar"`
+		} `json:"author"`
+	} `json:"data"`
+}
+
+func tikwmDownloader(ctx context.Context, postURL string) (*models.TiktokResult, error) {
+	form := url.Values{"url": {postURL}, "hd": {"1"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tikwmURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch tiktok post, status code: %d", resp.StatusCode)
+	}
+
+	var response TikwmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Code != 0 {
+		return nil, errors.New("failed to fetch tiktok post: " + response.Msg)
+	}
+
+	data := response.Data
+	result := &models.TiktokResult{
+		ID:             data.ID,
+		Type:           models.TIKTOK_VIDEO,
+		Provider:       "tikwm",
+		Nickname:       data.Author.Nickname,
+		Username:       "@" + data.Author.UniqueID,
+		Avatar:         data.Author.Avatar,
+		Description:    data.Title,
+		Hashtags:       Hashtags(data.Title),
+		Thumbnail:      data.Cover,
+		Played:         data.PlayCount,
+		Commented:      data.CommentCount,
+		Saved:          data.CollectCount,
+		Shared:         data.ShareCount,
+		Song:           strings.TrimSpace(data.MusicInfo.Title + " - " + data.MusicInfo.Author),
+		Video:          data.Play,
+		VideoHD:        data.HdPlay,
+		VideoWatermark: data.WmPlay,
+		Audio:          data.Music,
+		Images:         []string{},
+	}
+
+	if data.CreateTime > 0 {
+		result.CreatedAt = time.Unix(data.CreateTime, 0).UTC()
+	}
+
+	if len(data.Images) > 0 {
+		result.Type = models.TIKTOK_SLIDESHOW
+		result.Images = data.Images
+		result.Video = ""
+		result.VideoHD = ""
+		result.VideoWatermark = ""
+	}
+
+	return result, nil
+}
+
+// ParseCount turns display counters such as "1.2M", "15.3K" or "1,024" into
+// numbers. Anything it can't make sense of is 0.
+func ParseCount(s string) int64 {
+	match := rgxCount.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		value *= 1e3
+	case "M":
+		value *= 1e6
+	case "B":
+		value *= 1e9
+	}
+
+	return int64(value + 0.5)
+}
+
+func Hashtags(description string) []string {
+	hashtags := []string{}
+	seen := map[string]bool{}
+
+	for _, match := range rgxHashtag.FindAllStringSubmatch(description, -1) {
+		tag := strings.ToLower(match[1])
+		if !seen[tag] {
+			seen[tag] = true
+			hashtags = append(hashtags, tag)
+		}
+	}
+
+	return hashtags
+}
+
+// tiktokID returns the post ID from a full TikTok URL. Short vm.tiktok.com
+// and vt.tiktok.com links are followed to find it; nothing else is requested.
+func tiktokID(ctx context.Context, postURL string) string {
+	if match := rgxTiktokID.FindStringSubmatch(postURL); match != nil {
+		return match[1]
+	}
+
+	if !matchHost(postURL, "vm.tiktok.com", "vt.tiktok.com") {
+		return ""
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, postURL, nil)
+	if err != nil {
+		return ""
+	}
+
+	resp, err := tiktokClient.Do(req)
+	if err != nil {
+		return ""
+	}
+	resp.Body.Close()
+
+	if match := rgxTiktokID.FindStringSubmatch(resp.Request.URL.Path); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// tiktokCreatedAt recovers the post time from its ID, whose upper 32 bits are
+// the Unix time it was created at.
+func tiktokCreatedAt(id string) time.Time {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(n>>32), 0).UTC()
+}
+
+type Tiktok struct{}
+
+func (Tiktok) Name() string {
+	return "tiktok"
+}
+
+func (Tiktok) Match(rawURL string) bool {
+	return matchHost(rawURL, "tiktok.com")
+}
+
+func (Tiktok) Resolve(ctx context.Context, rawURL string) (*models.Result, error) {
+	post, err := TiktokDownloader(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Result{
+		Source:    "tiktok",
+		Title:     post.Description,
+		Author:    post.Username,
+		Thumbnail: post.Thumbnail,
+		Files:     []models.File{},
+	}
+
+	for _, file := range []models.File{
+		{URL: post.VideoHD, MimeType: "video/mp4", Quality: "hd"},
+		{URL: post.Video, MimeType: "video/mp4", Quality: "sd"},
+		{URL: post.VideoWatermark, MimeType: "video/mp4", Quality: "watermark"},
+		{URL: post.Audio, MimeType: "audio/mpeg", Quality: "audio"},
+	} {
+		if file.URL != "" {
+			result.Files = append(result.Files, file)
+		}
+	}
+
+	for _, image := range post.Images {
+		result.Files = append(result.Files, models.File{URL: image, MimeType: "image/jpeg", Quality: "image"})
+	}
+
 	return result, nil
 }
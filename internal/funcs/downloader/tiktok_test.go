@@ -0,0 +1,37 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTiktokID(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Redirect(w, r, "https://www.tiktok.com/@example/video/7300000000000000001", http.StatusFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.tiktok.com/@example/video/7300000000000000001?lang=en", "7300000000000000001"},
+		{"https://www.tiktok.com/@example/photo/7300000000000000002", "7300000000000000002"},
+		{srv.URL + "/ZS8abcdef/", ""},
+		{"http://127.0.0.1:1/@example", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tiktokID(context.Background(), tt.url); got != tt.want {
+			t.Errorf("tiktokID(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("got %d requests to a host other than a TikTok short link", requests)
+	}
+}
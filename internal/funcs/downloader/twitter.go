@@ -0,0 +1,209 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+// FxTwitterURL is the FxTwitter API used to look up posts on X, since X's own
+// API needs an authenticated account.
+var FxTwitterURL = "https://api.fxtwitter.com"
+
+var rgxTwitterStatus = regexp.MustCompile(`^/([A-Za-z0-9_]+)/status(?:es)?/(\d+)`)
+
+type FxTwitterResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Tweet   *struct {
+		ID               string `json:"id"`
+		URL              string `json:"url"`
+		Text             string `json:"text"`
+		CreatedTimestamp int64  `json:"created_timestamp"`
+		Likes            int64  `json:"likes"`
+		Replies          int64  `json:"replies"`
+		Retweets         int64  `json:"retweets"`
+		Views            int64  `json:"views"`
+		Author           struct {
+			ScreenName string `json:"screen_name"`
+			Name       string `json:"name"`
+			AvatarURL  string `json:"avatar_url"`
+			URL        string `json:"url"`
+		} `json:"author"`
+		Media *struct {
+			Photos []struct {
+				URL    string `json:"url"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"photos"`
+			Videos []struct {
+				URL          string `json:"url"`
+				ThumbnailURL string `json:"thumbnail_url"`
+				Width        int    `json:"width"`
+				Height       int    `json:"height"`
+				Format       string `json:"format"`
+				Variants     []struct {
+					URL         string `json:"url"`
+					Bitrate     int    `json:"bitrate"`
+					ContentType string `json:"content_type"`
+				} `json:"variants"`
+			} `json:"videos"`
+		} `json:"media"`
+	} `json:"tweet"`
+}
+
+func IsTwitterURL(rawURL string) bool {
+	return matchHost(rawURL, "twitter.com", "x.com", "fxtwitter.com", "vxtwitter.com") && twitterStatus(rawURL) != nil
+}
+
+// TwitterDownloader looks up a post on X with every photo and every video
+// variant it has, highest bitrate first.
+func TwitterDownloader(ctx context.Context, postURL string) (*models.SocialPost, error) {
+	status := twitterStatus(postURL)
+	if status == nil {
+		return nil, ErrUnsupported
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/status/%s", FxTwitterURL, url.PathEscape(status[1]), url.PathEscape(status[2]))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response FxTwitterResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case response.Code == http.StatusNotFound:
+		return nil, ErrFileDeleted
+	case response.Code == http.StatusUnauthorized || response.Code == http.StatusForbidden:
+		return nil, ErrAccessDenied
+	case response.Code != http.StatusOK || response.Tweet == nil:
+		return nil, fmt.Errorf("failed to fetch post: %s", response.Message)
+	}
+
+	tweet := response.Tweet
+	post := &models.SocialPost{
+		ID:       tweet.ID,
+		Platform: "twitter",
+		URL:      tweet.URL,
+		Author: models.SocialAuthor{
+			Username: "@" + tweet.Author.ScreenName,
+			Nickname: tweet.Author.Name,
+			Avatar:   tweet.Author.AvatarURL,
+			URL:      tweet.Author.URL,
+		},
+		Caption:   tweet.Text,
+		Hashtags:  Hashtags(tweet.Text),
+		Liked:     tweet.Likes,
+		Commented: tweet.Replies,
+		Played:    tweet.Views,
+		Shared:    tweet.Retweets,
+		Videos:    []models.MediaVariant{},
+		Images:    []models.MediaVariant{},
+	}
+
+	if tweet.CreatedTimestamp > 0 {
+		post.CreatedAt = time.Unix(tweet.CreatedTimestamp, 0).UTC()
+	}
+
+	if tweet.Media == nil {
+		return post, nil
+	}
+
+	for _, photo := range tweet.Media.Photos {
+		post.Images = append(post.Images, models.MediaVariant{
+			URL:      photo.URL,
+			MimeType: mimeTypeOf(strings.SplitN(photo.URL, "?", 2)[0]),
+			Width:    photo.Width,
+			Height:   photo.Height,
+		})
+	}
+
+	for _, video := range tweet.Media.Videos {
+		if post.Thumbnail == "" {
+			post.Thumbnail = video.ThumbnailURL
+		}
+
+		variants := video.Variants
+		sort.SliceStable(variants, func(i, j int) bool { return variants[i].Bitrate > variants[j].Bitrate })
+
+		if len(variants) == 0 {
+			post.Videos = append(post.Videos, models.MediaVariant{
+				URL:      video.URL,
+				MimeType: "video/mp4",
+				Quality:  qualityOf(video.Width, video.Height),
+				Width:    video.Width,
+				Height:   video.Height,
+			})
+			continue
+		}
+
+		for _, variant := range variants {
+			// HLS playlists aren't a downloadable file.
+			if variant.ContentType != "video/mp4" {
+				continue
+			}
+
+			width, height := twitterResolution(variant.URL)
+
+			post.Videos = append(post.Videos, models.MediaVariant{
+				URL:      variant.URL,
+				MimeType: variant.ContentType,
+				Quality:  qualityOf(width, height),
+				Width:    width,
+				Height:   height,
+				Bitrate:  variant.Bitrate,
+			})
+		}
+	}
+
+	if post.Thumbnail == "" && len(post.Images) > 0 {
+		post.Thumbnail = post.Images[0].URL
+	}
+
+	return post, nil
+}
+
+var rgxTwitterResolution = regexp.MustCompile(`/(\d+)x(\d+)/`)
+
+// twitterResolution reads the resolution X puts in video variant paths, e.g.
+// /vid/avc1/720x1280/.
+func twitterResolution(variantURL string) (int, int) {
+	match := rgxTwitterResolution.FindStringSubmatch(variantURL)
+	if match == nil {
+		return 0, 0
+	}
+
+	width, _ := strconv.Atoi(match[1])
+	height, _ := strconv.Atoi(match[2])
+
+	return width, height
+}
+
+func twitterStatus(rawURL string) []string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	return rgxTwitterStatus.FindStringSubmatch(u.Path)
+}
@@ -0,0 +1,79 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+func TestTwitterVideo(t *testing.T) {
+	stubUpstream(t, &FxTwitterURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example_dev/status/1786000000000000001" {
+			w.Write([]byte(`{"code":404,"message":"NOT_FOUND","tweet":null}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(fixture(t, "fxtwitter_video.json"))
+	})
+
+	post, err := TwitterDownloader(context.Background(), "https://x.com/example_dev/status/1786000000000000001?s=20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const base = "https://video.twimg.com/ext_tw_video/1786000000000000002/pu/vid/avc1/"
+	thumbnail := "https://pbs.twimg.com/ext_tw_video_thumb/1786000000000000002/pu/img/thumb.jpg"
+
+	want := &models.SocialPost{
+		ID:       "1786000000000000001",
+		Platform: "twitter",
+		URL:      "https://x.com/example_dev/status/1786000000000000001",
+		Author: models.SocialAuthor{
+			Username: "@example_dev",
+			Nickname: "Example Dev",
+			Avatar:   "https://pbs.twimg.com/profile_images/100000001/avatar_200x200.jpg",
+			URL:      "https://x.com/example_dev",
+		},
+		Caption:   "New trailer just dropped #anime #Trailer",
+		Hashtags:  []string{"anime", "trailer"},
+		Thumbnail: thumbnail,
+		CreatedAt: time.Unix(1714651200, 0).UTC(),
+		Liked:     2890,
+		Commented: 41,
+		Played:    184233,
+		Shared:    320,
+		// Highest bitrate first, without the HLS playlist.
+		Videos: []models.MediaVariant{
+			{URL: base + "720x1280/hQ.mp4?tag=12", MimeType: "video/mp4", Quality: "720p", Width: 720, Height: 1280, Bitrate: 2176000},
+			{URL: base + "320x568/lO.mp4?tag=12", MimeType: "video/mp4", Quality: "320p", Width: 320, Height: 568, Bitrate: 950000},
+			{URL: base + "480x852/mD.mp4?tag=12", MimeType: "video/mp4", Quality: "480p", Width: 480, Height: 852, Bitrate: 632000},
+		},
+		Images: []models.MediaVariant{},
+	}
+
+	if !reflect.DeepEqual(post, want) {
+		t.Errorf("got %+v; want %+v", post, want)
+	}
+
+	result := socialResult(post)
+	wantResult := &models.Result{
+		Source:    "twitter",
+		Title:     "New trailer just dropped #anime #Trailer",
+		Author:    "@example_dev",
+		Thumbnail: thumbnail,
+		Files: []models.File{
+			{URL: base + "720x1280/hQ.mp4?tag=12", MimeType: "video/mp4", Quality: "720p"},
+			{URL: base + "320x568/lO.mp4?tag=12", MimeType: "video/mp4", Quality: "320p"},
+			{URL: base + "480x852/mD.mp4?tag=12", MimeType: "video/mp4", Quality: "480p"},
+		},
+	}
+
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("got %+v; want %+v", result, wantResult)
+	}
+}
@@ -0,0 +1,98 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+// YoutubeURL is where oEmbed metadata for Shorts is fetched from.
+var YoutubeURL = "https://www.youtube.com"
+
+var rgxYoutubeShort = regexp.MustCompile(`^/shorts/([A-Za-z0-9_-]{11})`)
+
+type YoutubeOEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	AuthorURL    string `json:"author_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func IsYoutubeShortURL(rawURL string) bool {
+	return matchHost(rawURL, "youtube.com") && youtubeShortID(rawURL) != ""
+}
+
+// YoutubeShort returns the metadata of a YouTube Short. YouTube streams are
+// signed per client, so no media URLs are returned, only the thumbnail.
+func YoutubeShort(ctx context.Context, postURL string) (*models.SocialPost, error) {
+	id := youtubeShortID(postURL)
+	if id == "" {
+		return nil, ErrUnsupported
+	}
+
+	canonical := "https://www.youtube.com/shorts/" + id
+
+	params := url.Values{"url": {canonical}, "format": {"json"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/oembed?%s", YoutubeURL, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, ErrFileDeleted
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrAccessDenied
+	default:
+		return nil, fmt.Errorf("failed to fetch short, status code: %d", resp.StatusCode)
+	}
+
+	var response YoutubeOEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	post := &models.SocialPost{
+		ID:       id,
+		Platform: "youtube",
+		URL:      canonical,
+		Author: models.SocialAuthor{
+			Username: response.AuthorName,
+			Nickname: response.AuthorName,
+			URL:      response.AuthorURL,
+		},
+		Caption:   response.Title,
+		Hashtags:  Hashtags(response.Title),
+		Thumbnail: response.ThumbnailURL,
+		Videos:    []models.MediaVariant{},
+		Images:    []models.MediaVariant{},
+	}
+
+	return post, nil
+}
+
+func youtubeShortID(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	if match := rgxYoutubeShort.FindStringSubmatch(u.Path); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
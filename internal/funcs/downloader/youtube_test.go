@@ -0,0 +1,62 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	models "miruchigawa.moe/restapi/internal/models/downloader"
+)
+
+func TestYoutubeShort(t *testing.T) {
+	stubUpstream(t, &YoutubeURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oembed" || r.URL.Query().Get("url") != "https://www.youtube.com/shorts/dQw4w9WgXcQ" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(fixture(t, "youtube_oembed.json"))
+	})
+
+	post, err := YoutubeShort(context.Background(), "https://youtube.com/shorts/dQw4w9WgXcQ?si=abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thumbnail := "https://i.ytimg.com/vi/dQw4w9WgXcQ/hq2.jpg"
+	want := &models.SocialPost{
+		ID:       "dQw4w9WgXcQ",
+		Platform: "youtube",
+		URL:      "https://www.youtube.com/shorts/dQw4w9WgXcQ",
+		Author: models.SocialAuthor{
+			Username: "Example Kitchen",
+			Nickname: "Example Kitchen",
+			URL:      "https://www.youtube.com/@examplekitchen",
+		},
+		Caption:   "60 second ramen #shorts #Cooking",
+		Hashtags:  []string{"shorts", "cooking"},
+		Thumbnail: thumbnail,
+		Videos:    []models.MediaVariant{},
+		Images:    []models.MediaVariant{},
+	}
+
+	if !reflect.DeepEqual(post, want) {
+		t.Errorf("got %+v; want %+v", post, want)
+	}
+
+	// Shorts have no downloadable media, so only the metadata carries over.
+	result := socialResult(post)
+	wantResult := &models.Result{
+		Source:    "youtube",
+		Title:     "60 second ramen #shorts #Cooking",
+		Author:    "Example Kitchen",
+		Thumbnail: thumbnail,
+		Files:     []models.File{},
+	}
+
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("got %+v; want %+v", result, wantResult)
+	}
+}
@@ -0,0 +1,300 @@
+package manga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	models "miruchigawa.moe/restapi/internal/models/manga"
+)
+
+const defaultConcurrency = 4
+
+var ErrVolumeNotFound = errors.New("no chapters found for this volume")
+
+type ChapterResponse struct {
+	Result string `json:"result"`
+	Data   struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Title              *string   `json:"title"`
+			Volume             *string   `json:"volume"`
+			Chapter            *string   `json:"chapter"`
+			TranslatedLanguage string    `json:"translatedLanguage"`
+			Pages              int       `json:"pages"`
+			PublishAt          time.Time `json:"publishAt"`
+		} `json:"attributes"`
+		Relationships []Relationship `json:"relationships"`
+	} `json:"data"`
+}
+
+func Chapter(ctx context.Context, id string) (*models.Chapter, error) {
+	params := url.Values{}
+	params.Add("includes[]", "manga")
+	params.Add("includes[]", "scanlation_group")
+
+	resp, err := get(ctx, fmt.Sprintf("%s/chapter/%s?%s", apiURL, url.PathEscape(id), params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch chapter, status code: %d", resp.StatusCode)
+	}
+
+	var response ChapterResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Result != "ok" {
+		return nil, errors.New("failed to fetch chapter")
+	}
+
+	attrs := response.Data.Attributes
+	chapter := &models.Chapter{
+		ID:        response.Data.ID,
+		Title:     deref(attrs.Title),
+		Volume:    deref(attrs.Volume),
+		Chapter:   deref(attrs.Chapter),
+		Language:  attrs.TranslatedLanguage,
+		Pages:     attrs.Pages,
+		PublishAt: attrs.PublishAt,
+		Groups:    []string{},
+	}
+
+	for _, rel := range response.Data.Relationships {
+		switch rel.Type {
+		case "manga":
+			chapter.MangaID = rel.ID
+			if rel.Attributes != nil {
+				chapter.MangaTitle = localizedTitle(rel.Attributes.Title, rel.Attributes.AltTitles, chapter.Language)
+			}
+		case "scanlation_group":
+			if rel.Attributes != nil {
+				chapter.Groups = append(chapter.Groups, rel.Attributes.Name)
+			}
+		}
+	}
+
+	return chapter, nil
+}
+
+type AtHomeResponse struct {
+	Result  string `json:"result"`
+	BaseURL string `json:"baseUrl"`
+	Chapter struct {
+		Hash      string   `json:"hash"`
+		Data      []string `json:"data"`
+		DataSaver []string `json:"dataSaver"`
+	} `json:"chapter"`
+}
+
+// PageURLs returns the image URLs of a chapter's pages, in reading order, from
+// the MangaDex@Home server assigned to it.
+func PageURLs(ctx context.Context, id string, dataSaver bool) ([]string, error) {
+	resp, err := get(ctx, fmt.Sprintf("%s/at-home/server/%s", apiURL, url.PathEscape(id)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch at-home server, status code: %d", resp.StatusCode)
+	}
+
+	var response AtHomeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if response.Result != "ok" {
+		return nil, errors.New("failed to fetch at-home server")
+	}
+
+	quality, files := "data", response.Chapter.Data
+	if dataSaver {
+		quality, files = "data-saver", response.Chapter.DataSaver
+	}
+
+	urls := make([]string, len(files))
+	for i, file := range files {
+		urls[i] = fmt.Sprintf("%s/%s/%s/%s", response.BaseURL, quality, response.Chapter.Hash, file)
+	}
+
+	return urls, nil
+}
+
+// FetchPages downloads page images with at most a few requests in flight at
+// once. The returned slice is in the same order as urls. The first failure
+// cancels the remaining downloads.
+func FetchPages(ctx context.Context, urls []string) ([][]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]byte, len(urls))
+	errChan := make(chan error, len(urls))
+	sem := make(chan struct{}, defaultConcurrency)
+
+	for i, u := range urls {
+		go func(i int, u string) {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errChan <- ctx.Err()
+				return
+			}
+
+			data, err := fetchPage(ctx, u)
+			if err != nil {
+				cancel()
+			}
+			pages[i] = data
+			errChan <- err
+		}(i, u)
+	}
+
+	var firstErr error
+	for range urls {
+		if err := <-errChan; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return pages, nil
+}
+
+func fetchPage(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch page %s, status code: %d", u, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+type AggregateResponse struct {
+	Result  string                     `json:"result"`
+	Volumes map[string]json.RawMessage `json:"volumes"`
+}
+
+// VolumeChapters returns the IDs of a volume's chapters in chapter order,
+// picking one translation per chapter in the given language. It returns
+// ErrVolumeNotFound when the manga has no such volume in that language.
+func VolumeChapters(ctx context.Context, mangaID, volume, lang string) ([]string, error) {
+	params := url.Values{}
+	if lang != "" {
+		params.Add("translatedLanguage[]", lang)
+	}
+
+	resp, err := get(ctx, fmt.Sprintf("%s/manga/%s/aggregate?%s", apiURL, url.PathEscape(mangaID), params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrVolumeNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch manga volumes, status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AggregateResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		// MangaDex returns an empty array rather than an object when a manga
+		// has no chapters in the requested language.
+		var empty struct {
+			Volumes []json.RawMessage `json:"volumes"`
+		}
+		if json.Unmarshal(body, &empty) == nil {
+			return nil, ErrVolumeNotFound
+		}
+
+		return nil, err
+	}
+
+	raw, ok := response.Volumes[volume]
+	if !ok {
+		return nil, ErrVolumeNotFound
+	}
+
+	var vol struct {
+		Chapters map[string]struct {
+			Chapter string `json:"chapter"`
+			ID      string `json:"id"`
+		} `json:"chapters"`
+	}
+	if err := json.Unmarshal(raw, &vol); err != nil {
+		return nil, err
+	}
+
+	if len(vol.Chapters) == 0 {
+		return nil, ErrVolumeNotFound
+	}
+
+	type entry struct {
+		number float64
+		id     string
+	}
+
+	entries := make([]entry, 0, len(vol.Chapters))
+	for _, ch := range vol.Chapters {
+		number, _ := strconv.ParseFloat(ch.Chapter, 64)
+		entries = append(entries, entry{number: number, id: ch.ID})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].number < entries[j].number })
+
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.id
+	}
+
+	return ids, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func get(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(req)
+}
@@ -1,18 +1,29 @@
 package manga
 
 import (
-    "fmt"
-    "net/http"
-    "net/url"
-    "errors"
-    "encoding/json"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 
 	models "miruchigawa.moe/restapi/internal/models/manga"
 )
 
 var (
-    baseURL string = "https://mangadex.org"
-    apiURL string = "https://api.mangadex.org"
+	apiURL     string = "https://api.mangadex.org"
+	uploadsURL string = "https://uploads.mangadex.org"
+)
+
+var (
+	Statuses        = []string{"ongoing", "completed", "hiatus", "cancelled"}
+	Demographics    = []string{"shounen", "shoujo", "josei", "seinen", "none"}
+	ContentRatings  = []string{"safe", "suggestive", "erotica", "pornographic"}
+	OrderFields     = []string{"title", "year", "createdAt", "updatedAt", "latestUploadedChapter", "followedCount", "relevance", "rating"}
+	OrderDirections = []string{"asc", "desc"}
 )
 
 type MangadexSearchResponse struct {
@@ -20,47 +31,126 @@ type MangadexSearchResponse struct {
 	Data   []struct {
 		ID         string `json:"id"`
 		Attributes struct {
-			Title         map[string]string `json:"title"`
-			AltTitles     interface{}       `json:"altTitles"`
-			Description   map[string]string `json:"description"`
-			Status        string            `json:"status"`
-			Year          int               `json:"year"`
-			ContentRating string            `json:"contentRating"`
-			LastVolume    string            `json:"lastVolume"`
-			LastChapter   string            `json:"lastChapter"`
+			Title         map[string]string   `json:"title"`
+			AltTitles     []map[string]string `json:"altTitles"`
+			Description   map[string]string   `json:"description"`
+			Status        string              `json:"status"`
+			Year          int                 `json:"year"`
+			ContentRating string              `json:"contentRating"`
+			LastVolume    string              `json:"lastVolume"`
+			LastChapter   string              `json:"lastChapter"`
 		} `json:"attributes"`
-		Relationships []struct {
-			Type string `json:"type"`
-			ID   string `json:"id"`
-		} `json:"relationships"`
+		Relationships []Relationship `json:"relationships"`
 	} `json:"data"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+// Relationship is a related entity of a manga. Attributes are only present
+// when the relationship type was requested with includes[].
+type Relationship struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes *struct {
+		FileName  string              `json:"fileName"`
+		Name      string              `json:"name"`
+		Title     map[string]string   `json:"title"`
+		AltTitles []map[string]string `json:"altTitles"`
+	} `json:"attributes"`
+}
+
+type SearchOptions struct {
+	Title                       string
+	Page                        int
+	Limit                       int
+	IncludedTags                []string
+	ExcludedTags                []string
+	Status                      []string
+	PublicationDemographic      []string
+	ContentRating               []string
+	OriginalLanguage            []string
+	AvailableTranslatedLanguage []string
+	Year                        int
+	OrderField                  string
+	OrderDirection              string
+	Lang                        string
+}
+
+func Search(ctx context.Context, query string, page, limit int) (*models.SearchResults, error) {
+	return AdvancedSearch(ctx, SearchOptions{Title: query, Page: page, Limit: limit})
 }
 
-func Search(query string, page, limit int) (*models.SearchResults, error) {
-    if page <= 0 {
-        return nil, errors.New("page number must be greater than 0")
-    }
+func AdvancedSearch(ctx context.Context, opts SearchOptions) (*models.SearchResults, error) {
+	if opts.Page <= 0 {
+		return nil, errors.New("page number must be greater than 0")
+	}
+
+	if opts.Limit > 100 {
+		return nil, errors.New("limit must be less than or equal to 100")
+	}
+
+	if opts.Limit*(opts.Page-1) >= 10000 {
+		return nil, errors.New("not enough results")
+	}
+
+	if opts.Lang == "" {
+		opts.Lang = "en"
+	}
+
+	orderField, orderDirection := opts.OrderField, opts.OrderDirection
+	if orderField == "" {
+		orderField = "relevance"
+	}
+	if orderDirection == "" {
+		orderDirection = "desc"
+	}
 
-    if limit > 100 {
-        return nil, errors.New("limit must be less than or equal to 100")
-    }
+	params := url.Values{}
+	params.Add("includes[]", "cover_art")
+	params.Add("includes[]", "author")
+	params.Add("includes[]", "artist")
+	params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+	params.Set("offset", fmt.Sprintf("%d", opts.Limit*(opts.Page-1)))
+	if opts.Title != "" {
+		params.Set("title", opts.Title)
+	}
+	if opts.Year > 0 {
+		params.Set("year", strconv.Itoa(opts.Year))
+	}
+	if orderField != "relevance" || opts.Title != "" {
+		params.Set(fmt.Sprintf("order[%s]", orderField), orderDirection)
+	}
 
-    if limit*(page-1) >= 10000 {
-        return nil, errors.New("not enough results")
-    }
+	for key, values := range map[string][]string{
+		"includedTags[]":                opts.IncludedTags,
+		"excludedTags[]":                opts.ExcludedTags,
+		"status[]":                      opts.Status,
+		"publicationDemographic[]":      opts.PublicationDemographic,
+		"contentRating[]":               opts.ContentRating,
+		"originalLanguage[]":            opts.OriginalLanguage,
+		"availableTranslatedLanguage[]": opts.AvailableTranslatedLanguage,
+	} {
+		for _, value := range values {
+			params.Add(key, value)
+		}
+	}
 
-    params := url.Values{}
-	params.Set("limit", fmt.Sprintf("%d", limit))
-	params.Set("title", query)
-	params.Set("offset", fmt.Sprintf("%d", limit*(page-1)))
-	params.Set("order[relevance]", "desc")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/manga?%s", apiURL, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := http.Get(fmt.Sprintf("%s/manga?%s", apiURL, params.Encode()))
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch manga results, status code: %d", resp.StatusCode)
+	}
+
 	var response MangadexSearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		return nil, err
@@ -71,67 +161,126 @@ func Search(query string, page, limit int) (*models.SearchResults, error) {
 	}
 
 	results := &models.SearchResults{
-	    CurrentPage: page,
-	    Results: []models.MangaInfo{},
+		CurrentPage: opts.Page,
+		HasNextPage: response.Offset+len(response.Data) < response.Total,
+		Total:       response.Total,
+		Results:     []models.MangaInfo{},
 	}
 
 	for _, manga := range response.Data {
-        var coverArt string
+		info := models.MangaInfo{
+			ID:            manga.ID,
+			Title:         localizedTitle(manga.Attributes.Title, manga.Attributes.AltTitles, opts.Lang),
+			AltTitles:     manga.Attributes.AltTitles,
+			Description:   localized(manga.Attributes.Description, opts.Lang),
+			Status:        manga.Attributes.Status,
+			ReleaseDate:   manga.Attributes.Year,
+			ContentRating: manga.Attributes.ContentRating,
+			LastVolume:    manga.Attributes.LastVolume,
+			LastChapter:   manga.Attributes.LastChapter,
+			Authors:       []models.Creator{},
+			Artists:       []models.Creator{},
+			Thumbnails:    map[string]string{},
+		}
+
 		for _, rel := range manga.Relationships {
-			if rel.Type == "cover_art" {
-				coverArt, err = fetchCoverImage(rel.ID)
-				if err != nil {
-					return nil, err
+			switch {
+			case rel.Attributes == nil:
+				continue
+			case rel.Type == "cover_art" && rel.Attributes.FileName != "":
+				info.Image = fmt.Sprintf("%s/covers/%s/%s", uploadsURL, manga.ID, rel.Attributes.FileName)
+				for _, size := range []string{"256", "512"} {
+					info.Thumbnails[size] = fmt.Sprintf("%s.%s.jpg", info.Image, size)
 				}
-				break
+			case rel.Type == "author":
+				info.Authors = append(info.Authors, models.Creator{ID: rel.ID, Name: rel.Attributes.Name})
+			case rel.Type == "artist":
+				info.Artists = append(info.Artists, models.Creator{ID: rel.ID, Name: rel.Attributes.Name})
 			}
 		}
 
-	    results.Results = append(results.Results, models.MangaInfo{
-	        ID: manga.ID,
-	        Title: manga.Attributes.Title["en"],
-	        AltTitles: manga.Attributes.AltTitles,
-	        Description: manga.Attributes.Description["en"],
-	        Status: manga.Attributes.Status,
-	        ReleaseDate: manga.Attributes.Year,
-	        ContentRating: manga.Attributes.ContentRating,
-			LastVolume:    manga.Attributes.LastVolume,
-			LastChapter:   manga.Attributes.LastChapter,
-			Image: fmt.Sprintf("%s/covers/%s/%s", baseURL, manga.ID, coverArt),
-	    })
+		results.Results = append(results.Results, info)
 	}
 
 	return results, nil
 }
 
-
-type CoverResponse struct {
-	Data struct {
+type TagResponse struct {
+	Result string `json:"result"`
+	Data   []struct {
+		ID         string `json:"id"`
 		Attributes struct {
-			FileName string `json:"fileName"`
+			Name  map[string]string `json:"name"`
+			Group string            `json:"group"`
 		} `json:"attributes"`
 	} `json:"data"`
 }
 
-func fetchCoverImage(id string) (string, error) {
-    url := fmt.Sprintf("%s/cover/%s", apiURL, id)
-
-    resp, err := http.Get(url)
+func Tags(lang string) ([]models.Tag, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/manga/tag", apiURL))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to fetch cover image, status code: %d", resp.StatusCode)
+	var response TagResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
 	}
 
-	var response CoverResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", err
+	if response.Result != "ok" {
+		return nil, errors.New("failed to fetch manga tags")
+	}
+
+	tags := []models.Tag{}
+	for _, tag := range response.Data {
+		tags = append(tags, models.Tag{
+			ID:    tag.ID,
+			Name:  localized(tag.Attributes.Name, lang),
+			Group: tag.Attributes.Group,
+		})
+	}
+
+	return tags, nil
+}
+
+// localizedTitle picks the title in lang, falling back through the
+// alternative titles before settling for English, romanized Japanese or
+// whatever is available.
+func localizedTitle(title map[string]string, altTitles []map[string]string, lang string) string {
+	for _, l := range []string{lang, "en", "ja-ro"} {
+		if t := title[l]; t != "" {
+			return t
+		}
+
+		for _, alt := range altTitles {
+			if t := alt[l]; t != "" {
+				return t
+			}
+		}
+	}
+
+	// Map order is random, so the remaining languages are tried in order
+	// to settle on the same title every time.
+	langs := make([]string, 0, len(title))
+	for l := range title {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+
+	for _, l := range langs {
+		if t := title[l]; t != "" {
+			return t
+		}
 	}
 
-	return response.Data.Attributes.FileName, nil
+	return ""
 }
 
+func localized(values map[string]string, lang string) string {
+	if v := values[lang]; v != "" {
+		return v
+	}
 
+	return values["en"]
+}
@@ -0,0 +1,201 @@
+package mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miruchigawa.moe/restapi/internal/funcs/anilist"
+	"miruchigawa.moe/restapi/internal/funcs/search"
+	models "miruchigawa.moe/restapi/internal/models/anime"
+)
+
+const (
+	ProviderAnitaku = "anitaku"
+
+	// ConfidenceThreshold is the confidence a heuristic match needs before it
+	// is treated as confirmed and stored.
+	ConfidenceThreshold = 0.8
+)
+
+var (
+	Providers = []string{ProviderAnitaku}
+	Externals = []string{"anilist", "mal", "kitsu"}
+)
+
+var KitsuURL = "https://kitsu.io/api/edge"
+
+// ErrKitsu is wrapped by the error Match returns when only the Kitsu lookup
+// failed. The mapping is still returned then, without a Kitsu ID.
+var ErrKitsu = errors.New("kitsu lookup failed")
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Match looks up AniList candidates for a provider anime and picks the one
+// whose titles, start year and episode count agree best with the scraped
+// info. The Kitsu ID is resolved from the MyAnimeList ID when there is one; if
+// that lookup fails the mapping is returned along with an ErrKitsu error.
+func Match(provider string, info *models.AnimeInfo) (*models.Mapping, error) {
+	title := strings.TrimSpace(strings.Replace(info.Title, "(Dub)", "", 1))
+
+	candidates, err := anilist.SearchAnime(title)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &models.Mapping{
+		Provider:   provider,
+		ProviderID: info.ID,
+	}
+
+	var best *anilist.Media
+	for i := range candidates {
+		confidence := score(info, candidates[i])
+		if confidence > mapping.Confidence {
+			mapping.Confidence = confidence
+			best = &candidates[i]
+		}
+	}
+
+	if best == nil {
+		return mapping, nil
+	}
+
+	mapping.AniListID = best.ID
+	mapping.MalID = best.IDMal
+
+	if best.IDMal != 0 {
+		kitsuID, err := kitsuFromMal(best.IDMal)
+		if err != nil {
+			return mapping, fmt.Errorf("%w: %w", ErrKitsu, err)
+		}
+		mapping.KitsuID = kitsuID
+	}
+
+	return mapping, nil
+}
+
+func score(info *models.AnimeInfo, candidate anilist.Media) float64 {
+	titles := []string{info.Title}
+	for _, name := range strings.FieldsFunc(info.OtherName, func(r rune) bool { return r == ',' || r == ';' }) {
+		titles = append(titles, name)
+	}
+
+	titleScore := 0.0
+	for _, a := range titles {
+		for _, b := range candidate.Titles() {
+			if s := similarity(a, b); s > titleScore {
+				titleScore = s
+			}
+		}
+	}
+
+	yearScore := 0.5
+	if year, err := strconv.Atoi(strings.TrimSpace(info.ReleaseDate)); err == nil && candidate.Year() != 0 {
+		switch diff := year - candidate.Year(); {
+		case diff == 0:
+			yearScore = 1
+		case diff == 1 || diff == -1:
+			yearScore = 0.5
+		default:
+			yearScore = 0
+		}
+	}
+
+	episodeScore := 0.5
+	if info.TotalEpisodes > 0 && candidate.Episodes > 0 {
+		switch {
+		case info.TotalEpisodes == candidate.Episodes:
+			episodeScore = 1
+		case info.Status == models.ONGOING && info.TotalEpisodes < candidate.Episodes:
+			episodeScore = 0.7
+		default:
+			episodeScore = 0
+		}
+	}
+
+	return 0.7*titleScore + 0.15*yearScore + 0.15*episodeScore
+}
+
+// similarity is the Sørensen–Dice coefficient of the character bigrams of two
+// normalized titles.
+func similarity(a, b string) float64 {
+	a, b = search.NormalizeTitle(a), search.NormalizeTitle(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	bigrams := func(s string) map[string]int {
+		runes := []rune(s)
+		counts := map[string]int{}
+		for i := 0; i < len(runes)-1; i++ {
+			counts[string(runes[i:i+2])]++
+		}
+		return counts
+	}
+
+	ba, bb := bigrams(a), bigrams(b)
+
+	total, shared := 0, 0
+	for bigram, n := range ba {
+		total += n
+		shared += min(n, bb[bigram])
+	}
+	for _, n := range bb {
+		total += n
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 2 * float64(shared) / float64(total)
+}
+
+func kitsuFromMal(malID int) (int, error) {
+	params := url.Values{}
+	params.Set("filter[externalSite]", "myanimelist/anime")
+	params.Set("filter[externalId]", strconv.Itoa(malID))
+	params.Set("include", "item")
+
+	resp, err := client.Get(fmt.Sprintf("%s/mappings?%s", KitsuURL, params.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch kitsu mapping, status code: %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data []struct {
+			Relationships struct {
+				Item struct {
+					Data struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"item"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, err
+	}
+
+	if len(response.Data) == 0 {
+		return 0, nil
+	}
+
+	id, _ := strconv.Atoi(response.Data[0].Relationships.Item.Data.ID)
+	return id, nil
+}
@@ -0,0 +1,296 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"miruchigawa.moe/restapi/internal/funcs/anime"
+	"miruchigawa.moe/restapi/internal/funcs/manga"
+	models "miruchigawa.moe/restapi/internal/models/search"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Source is one site searched by Search. Its Search func is given a context
+// which is done once the search times out or its caller goes away, and should
+// abandon its requests then.
+type Source struct {
+	Name   string
+	Kind   models.Kind
+	Weight float64
+	Search func(ctx context.Context, query string) ([]models.Result, error)
+}
+
+var (
+	mu      sync.RWMutex
+	sources = []Source{
+		{Name: "anitaku", Kind: models.ANIME, Weight: 1, Search: searchAnitaku},
+		{Name: "mangadex", Kind: models.MANGA, Weight: 1, Search: searchMangadex},
+	}
+)
+
+// Register adds a source to the set queried by Search, replacing any existing
+// source with the same name.
+func Register(source Source) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range sources {
+		if sources[i].Name == source.Name {
+			sources[i] = source
+			return
+		}
+	}
+
+	sources = append(sources, source)
+}
+
+func Sources() []Source {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]Source(nil), sources...)
+}
+
+// Search queries every registered source concurrently and merges the results.
+// A source which fails or doesn't answer within the timeout is reported in
+// Errors instead of failing the whole search. Weights override the default
+// weight of a source by name when ranking.
+func Search(ctx context.Context, query string, weights map[string]float64) *models.Results {
+	return SearchStream(ctx, query, weights, nil)
+}
+
+// SearchStream is Search for callers that want partial results. onSource, if
+// not nil, is called with the scored results of each source as it answers, or
+// with its error, before the merged results are deduplicated and ranked.
+func SearchStream(ctx context.Context, query string, weights map[string]float64, onSource func(source string, results []models.Result, err error)) *models.Results {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	type sourceResult struct {
+		source  Source
+		results []models.Result
+		err     error
+	}
+
+	srcs := Sources()
+	resultChan := make(chan sourceResult, len(srcs))
+
+	for _, source := range srcs {
+		go func(source Source) {
+			defer func() {
+				if err := recover(); err != nil {
+					resultChan <- sourceResult{source: source, err: fmt.Errorf("%s", err)}
+				}
+			}()
+
+			results, err := source.Search(ctx, query)
+			resultChan <- sourceResult{source: source, results: results, err: err}
+		}(source)
+	}
+
+	merged := &models.Results{
+		Query:   query,
+		Results: []models.Result{},
+		Errors:  []models.SourceError{},
+	}
+	pending := map[string]bool{}
+	for _, source := range srcs {
+		pending[source.Name] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case res := <-resultChan:
+			delete(pending, res.source.Name)
+
+			if res.err != nil {
+				merged.Errors = append(merged.Errors, models.SourceError{Source: res.source.Name, Message: res.err.Error()})
+				if onSource != nil {
+					onSource(res.source.Name, nil, res.err)
+				}
+				continue
+			}
+
+			weight := res.source.Weight
+			if w, ok := weights[res.source.Name]; ok {
+				weight = w
+			}
+
+			scored := make([]models.Result, 0, len(res.results))
+			for i, result := range res.results {
+				result.Kind = res.source.Kind
+				result.Source = res.source.Name
+				result.Score = weight * score(query, result.Title, i)
+				scored = append(scored, result)
+			}
+			merged.Results = append(merged.Results, scored...)
+
+			if onSource != nil {
+				onSource(res.source.Name, scored, nil)
+			}
+		case <-ctx.Done():
+			for name := range pending {
+				merged.Errors = append(merged.Errors, models.SourceError{Source: name, Message: ctx.Err().Error()})
+				if onSource != nil {
+					onSource(name, nil, ctx.Err())
+				}
+			}
+			pending = nil
+		}
+	}
+
+	merged.Results = deduplicate(merged.Results)
+
+	sort.SliceStable(merged.Results, func(i, j int) bool {
+		return merged.Results[i].Score > merged.Results[j].Score
+	})
+
+	sort.Slice(merged.Errors, func(i, j int) bool {
+		return merged.Errors[i].Source < merged.Errors[j].Source
+	})
+
+	return merged
+}
+
+// ParseWeights parses weights in the form "source:weight,source:weight".
+func ParseWeights(s string) (map[string]float64, error) {
+	weights := map[string]float64{}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight %q", pair)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q", pair)
+		}
+
+		weights[strings.TrimSpace(name)] = weight
+	}
+
+	return weights, nil
+}
+
+func NormalizeTitle(title string) string {
+	title = strings.ToLower(title)
+	title = strings.ReplaceAll(title, "(dub)", "")
+
+	var b strings.Builder
+	space := false
+	for _, r := range title {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func deduplicate(results []models.Result) []models.Result {
+	seen := map[string]int{}
+	deduped := []models.Result{}
+
+	for _, result := range results {
+		key := string(result.Kind) + ":" + NormalizeTitle(result.Title)
+
+		if i, ok := seen[key]; ok {
+			if result.Score > deduped[i].Score {
+				deduped[i] = result
+			}
+			continue
+		}
+
+		seen[key] = len(deduped)
+		deduped = append(deduped, result)
+	}
+
+	return deduped
+}
+
+// score ranks a result by how closely its title matches the query, with a
+// smaller contribution from the position the source returned it in.
+func score(query, title string, rank int) float64 {
+	q := strings.Fields(NormalizeTitle(query))
+	t := strings.Fields(NormalizeTitle(title))
+
+	if len(q) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	words := map[string]bool{}
+	for _, word := range t {
+		words[word] = true
+	}
+
+	matched := 0
+	for _, word := range q {
+		if words[word] {
+			matched++
+		}
+	}
+
+	similarity := 2 * float64(matched) / float64(len(q)+len(t))
+	if strings.Join(q, " ") == strings.Join(t, " ") {
+		similarity = 1
+	}
+
+	return 0.7*similarity + 0.3/float64(1+rank)
+}
+
+func searchAnitaku(ctx context.Context, query string) ([]models.Result, error) {
+	result, err := anime.Search(ctx, query, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.Result, 0, len(result.Results))
+	for _, r := range result.Results {
+		year, _ := strconv.Atoi(strings.TrimSpace(r.ReleaseDate))
+		results = append(results, models.Result{
+			ID:    r.ID,
+			Title: r.Title,
+			Image: r.Image,
+			Year:  year,
+		})
+	}
+
+	return results, nil
+}
+
+func searchMangadex(ctx context.Context, query string) ([]models.Result, error) {
+	result, err := manga.Search(ctx, query, 1, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.Result, 0, len(result.Results))
+	for _, r := range result.Results {
+		results = append(results, models.Result{
+			ID:    r.ID,
+			Title: r.Title,
+			Image: r.Image,
+			Year:  r.ReleaseDate,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,175 @@
+package imageproxy
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Cache is a size-bounded disk cache. Once the files in it add up to more than
+// maxBytes, the least recently used ones are removed.
+type Cache struct {
+	dir      string
+	maxBytes int64
+	mu       sync.Mutex
+	size     int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	name string
+	size int64
+}
+
+func NewCache(dir string, maxBytes int64) (*Cache, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files left over from a previous run are picked up oldest first, so the
+	// most recently written ones are the last to be evicted.
+	type existing struct {
+		entry   cacheEntry
+		modTime int64
+	}
+
+	var found []existing
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		key := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		found = append(found, existing{
+			entry:   cacheEntry{key: key, name: file.Name(), size: info.Size()},
+			modTime: info.ModTime().UnixNano(),
+		})
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].modTime < found[j].modTime })
+
+	for _, f := range found {
+		c.entries[f.entry.key] = c.order.PushFront(f.entry)
+		c.size += f.entry.size
+	}
+
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+// Get returns the cached data for key and the file extension it was stored
+// with.
+func (c *Cache) Get(key string) ([]byte, string, bool) {
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if ok {
+		c.order.MoveToFront(elem)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, "", false
+	}
+
+	entry := elem.Value.(cacheEntry)
+
+	data, err := os.ReadFile(filepath.Join(c.dir, entry.name))
+	if err != nil {
+		c.remove(key)
+		return nil, "", false
+	}
+
+	return data, filepath.Ext(entry.name), true
+}
+
+func (c *Cache) Put(key, ext string, data []byte) error {
+	name := key + ext
+
+	// Writing to a temporary file first means a concurrent Get never sees a
+	// partially written image.
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.size -= elem.Value.(cacheEntry).size
+		c.order.Remove(elem)
+	}
+
+	entry := cacheEntry{key: key, name: name, size: int64(len(data))}
+	c.entries[key] = c.order.PushFront(entry)
+	c.size += entry.size
+
+	c.evict()
+	return nil
+}
+
+func (c *Cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.size -= elem.Value.(cacheEntry).size
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+func (c *Cache) evict() {
+	for c.size > c.maxBytes && c.order.Len() > 0 {
+		elem := c.order.Back()
+		entry := elem.Value.(cacheEntry)
+
+		os.Remove(filepath.Join(c.dir, entry.name))
+		c.order.Remove(elem)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+	}
+}
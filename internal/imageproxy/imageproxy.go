@@ -0,0 +1,302 @@
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	MaxDimension = 2000
+
+	maxUpstreamBytes = 20 << 20
+	maxRedirects     = 5
+	jpegQuality      = 85
+
+	// maxPixels bounds the size of a decoded image. Compressed images can
+	// declare far larger dimensions than their size suggests.
+	maxPixels = 50_000_000
+)
+
+// Formats are the output formats images can be converted to. WebP is accepted
+// as input but not produced, since there is no lossy WebP encoder in pure Go.
+var Formats = []string{"jpeg", "png"}
+
+var (
+	ErrHostNotAllowed = errors.New("image host is not allowed")
+	ErrNotImage       = errors.New("upstream did not return an image")
+	ErrTooLarge       = errors.New("upstream image is too large")
+)
+
+var contentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+type Options struct {
+	Width  int
+	Height int
+	Format string
+}
+
+type Image struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+type Proxy struct {
+	hosts  []string
+	cache  *Cache
+	client *http.Client
+}
+
+// New returns a proxy which only fetches images from the given hosts or their
+// subdomains. Redirects are held to the same hosts, and connections to
+// loopback, private and other non-public addresses are refused whatever the
+// host name resolves to.
+func New(hosts []string, cache *Cache) *Proxy {
+	p := &Proxy{
+		hosts: hosts,
+		cache: cache,
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !publicAddr(addr) {
+				return ErrHostNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// The address check would apply to the proxy instead of the image host.
+	transport.Proxy = nil
+
+	p.client = &http.Client{
+		Timeout:   15 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			if !p.Allowed(req.URL.String()) {
+				return ErrHostNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	return p
+}
+
+func (p *Proxy) Allowed(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range p.hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publicAddr reports whether addr is a globally routable unicast address.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which IsPrivate doesn't
+// cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Get fetches the image at rawURL and resizes and re-encodes it according to
+// opts, serving it from the disk cache when the same image was requested with
+// the same options before.
+func (p *Proxy) Get(ctx context.Context, rawURL string, opts Options) (*Image, error) {
+	if !p.Allowed(rawURL) {
+		return nil, ErrHostNotAllowed
+	}
+
+	key := cacheKey(rawURL, opts)
+
+	if data, ext, ok := p.cache.Get(key); ok {
+		return newImage(data, contentTypes[ext]), nil
+	}
+
+	original, err := p.fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ext, err := transform(original, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.cache.Put(key, ext, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return newImage(data, contentTypes[ext]), nil
+}
+
+func (p *Proxy) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hotlink protection usually only checks that the referer is the site
+	// itself.
+	req.Header.Set("Referer", fmt.Sprintf("%s://%s/", req.URL.Scheme, req.URL.Host))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch image, status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxUpstreamBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxUpstreamBytes {
+		return nil, ErrTooLarge
+	}
+
+	return data, nil
+}
+
+// transform resizes data to fit within the requested width and height,
+// keeping its aspect ratio and never enlarging it. Without any options the
+// original is passed through untouched so animated images keep working.
+func transform(data []byte, opts Options) ([]byte, string, error) {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", ErrNotImage
+	}
+
+	if opts.Width == 0 && opts.Height == 0 && opts.Format == "" {
+		for ext, ct := range contentTypes {
+			if ct == contentType {
+				return data, ext, nil
+			}
+		}
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrNotImage
+	}
+
+	if int64(config.Width)*int64(config.Height) > maxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrNotImage
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), opts.Width, opts.Height)
+
+	dst := src
+	if width != bounds.Dx() || height != bounds.Dy() {
+		resized := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(resized, resized.Bounds(), src, bounds, draw.Over, nil)
+		dst = resized
+	}
+
+	outFormat := opts.Format
+	if outFormat == "" {
+		outFormat = "jpeg"
+		if format == "png" || format == "gif" {
+			outFormat = "png"
+		}
+	}
+
+	var buf bytes.Buffer
+
+	switch outFormat {
+	case "png":
+		err = png.Encode(&buf, dst)
+		return buf.Bytes(), ".png", err
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+		return buf.Bytes(), ".jpg", err
+	}
+}
+
+func fit(srcWidth, srcHeight, maxWidth, maxHeight int) (int, int) {
+	if maxWidth <= 0 || maxWidth > srcWidth {
+		maxWidth = srcWidth
+	}
+	if maxHeight <= 0 || maxHeight > srcHeight {
+		maxHeight = srcHeight
+	}
+
+	scale := min(float64(maxWidth)/float64(srcWidth), float64(maxHeight)/float64(srcHeight))
+
+	return max(int(float64(srcWidth)*scale+0.5), 1), max(int(float64(srcHeight)*scale+0.5), 1)
+}
+
+func cacheKey(rawURL string, opts Options) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%d\x00%s", rawURL, opts.Width, opts.Height, opts.Format)))
+	return hex.EncodeToString(sum[:])
+}
+
+func newImage(data []byte, contentType string) *Image {
+	sum := sha256.Sum256(data)
+
+	return &Image{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+}
@@ -0,0 +1,286 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	QUEUED    Status = "QUEUED"
+	RUNNING   Status = "RUNNING"
+	COMPLETED Status = "COMPLETED"
+	FAILED    Status = "FAILED"
+)
+
+var ErrQueueFull = errors.New("job queue is full")
+
+const queueSize = 64
+
+type Job struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Status    Status    `json:"status"`
+	Progress  float64   `json:"progress"`
+	Error     string    `json:"error,omitempty"`
+	FileName  string    `json:"fileName,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Func is the work a job does. It reports progress and writes its output
+// through the handle it is given.
+type Func func(ctx context.Context, h *Handle) error
+
+type entry struct {
+	job  Job
+	fn   Func
+	path string
+}
+
+// Queue runs jobs in the background on a fixed number of workers. Job state is
+// kept in memory and output files are written under dir; both are dropped once
+// a finished job is older than ttl.
+type Queue struct {
+	dir     string
+	ttl     time.Duration
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	pending chan *entry
+	notify  func(Job)
+}
+
+func New(dir string, ttl time.Duration) (*Queue, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Queue{
+		dir:     dir,
+		ttl:     ttl,
+		jobs:    map[string]*entry{},
+		pending: make(chan *entry, queueSize),
+	}
+
+	return q, nil
+}
+
+// Run starts the workers and blocks until ctx is cancelled and every running
+// job has returned.
+func (q *Queue) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			q.cleanup()
+		}
+	}
+}
+
+// OnUpdate sets a function called with a job whenever it is queued or
+// changes. It is called outside the queue's lock, so it may call back into
+// the queue, but shouldn't block for long.
+func (q *Queue) OnUpdate(fn func(Job)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.notify = fn
+}
+
+func (q *Queue) Enqueue(kind string, fn Func) (Job, error) {
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	now := time.Now().UTC()
+	e := &entry{
+		job: Job{
+			ID:        id,
+			Kind:      kind,
+			Status:    QUEUED,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		fn: fn,
+	}
+
+	q.mu.Lock()
+
+	select {
+	case q.pending <- e:
+	default:
+		q.mu.Unlock()
+		return Job{}, ErrQueueFull
+	}
+
+	q.jobs[id] = e
+	job, notify := e.job, q.notify
+	q.mu.Unlock()
+
+	if notify != nil {
+		notify(job)
+	}
+
+	return job, nil
+}
+
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	return e.job, true
+}
+
+// Open returns the output file of a completed job. The caller closes it.
+func (q *Queue) Open(id string) (*os.File, Job, error) {
+	q.mu.Lock()
+	e, ok := q.jobs[id]
+	var job Job
+	var path string
+	if ok {
+		job, path = e.job, e.path
+	}
+	q.mu.Unlock()
+
+	if !ok || job.Status != COMPLETED || path == "" {
+		return nil, job, os.ErrNotExist
+	}
+
+	f, err := os.Open(path)
+	return f, job, err
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-q.pending:
+			q.update(e, func(job *Job) { job.Status = RUNNING })
+
+			err := q.execute(ctx, e)
+
+			q.update(e, func(job *Job) {
+				if err != nil {
+					job.Status = FAILED
+					job.Error = err.Error()
+					return
+				}
+
+				job.Status = COMPLETED
+				job.Progress = 1
+			})
+		}
+	}
+}
+
+func (q *Queue) execute(ctx context.Context, e *entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", r)
+		}
+	}()
+
+	return e.fn(ctx, &Handle{queue: q, entry: e})
+}
+
+func (q *Queue) update(e *entry, fn func(job *Job)) {
+	q.mu.Lock()
+	fn(&e.job)
+	e.job.UpdatedAt = time.Now().UTC()
+	job, notify := e.job, q.notify
+	q.mu.Unlock()
+
+	if notify != nil {
+		notify(job)
+	}
+}
+
+func (q *Queue) cleanup() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cutoff := time.Now().UTC().Add(-q.ttl)
+
+	for id, e := range q.jobs {
+		if e.job.Status != COMPLETED && e.job.Status != FAILED {
+			continue
+		}
+
+		if e.job.UpdatedAt.Before(cutoff) {
+			if e.path != "" {
+				os.Remove(e.path)
+			}
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// Handle is given to a running job.
+type Handle struct {
+	queue *Queue
+	entry *entry
+}
+
+func (h *Handle) SetProgress(progress float64) {
+	h.queue.update(h.entry, func(job *Job) { job.Progress = min(max(progress, 0), 1) })
+}
+
+// CreateFile creates the job's output file. name is what the file is offered
+// to the client as when it is downloaded.
+func (h *Handle) CreateFile(name string) (*os.File, error) {
+	path := filepath.Join(h.queue.dir, h.entry.job.ID)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h.queue.mu.Lock()
+	h.entry.path = path
+	h.entry.job.FileName = name
+	h.queue.mu.Unlock()
+
+	return f, nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
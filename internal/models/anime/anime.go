@@ -1,18 +1,65 @@
 package anime
 
+import "time"
+
 type SearchResult struct {
-	CurrentPage int
-	HasNextPage bool
-	Results     []AnimeResult
+	CurrentPage int           `json:"currentPage"`
+	HasNextPage bool          `json:"hasNextPage"`
+	Results     []AnimeResult `json:"results"`
+}
+
+func (r SearchResult) Pagination() (page, limit, total int, hasNext bool) {
+	return r.CurrentPage, 0, 0, r.HasNextPage
+}
+
+func (r SearchResult) Items() any {
+	return r.Results
 }
 
 type AnimeResult struct {
-	ID          string
-	Title       string
-	URL         string
-	Image       string
-	ReleaseDate string
-	SubOrDub    SubOrDub
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	Image       string   `json:"image"`
+	ReleaseDate string   `json:"releaseDate"`
+	SubOrDub    SubOrDub `json:"subOrDub"`
+}
+
+type RecentReleases struct {
+	CurrentPage int             `json:"currentPage"`
+	HasNextPage bool            `json:"hasNextPage"`
+	Results     []RecentRelease `json:"results"`
+}
+
+func (r RecentReleases) Pagination() (page, limit, total int, hasNext bool) {
+	return r.CurrentPage, 0, 0, r.HasNextPage
+}
+
+func (r RecentReleases) Items() any {
+	return r.Results
+}
+
+type RecentRelease struct {
+	ID            string   `json:"id"`
+	EpisodeID     string   `json:"episodeId"`
+	EpisodeNumber float64  `json:"episodeNumber"`
+	Title         string   `json:"title"`
+	URL           string   `json:"url"`
+	Image         string   `json:"image"`
+	SubOrDub      SubOrDub `json:"subOrDub"`
+}
+
+type ReleaseType int
+
+const (
+	RELEASE_SUB     ReleaseType = 1
+	RELEASE_DUB     ReleaseType = 2
+	RELEASE_CHINESE ReleaseType = 3
+)
+
+type Genre struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type MediaFormat string
@@ -39,28 +86,137 @@ const (
 )
 
 type Episode struct {
-	ID     string
-	Number float64
-	URL    string
+	ID     string  `json:"id"`
+	Number float64 `json:"number"`
+	URL    string  `json:"url"`
 }
 
 type AnimeInfo struct {
-	ID            string
-	Title         string
-	URL           string
-	Image         string
-	ReleaseDate   string
-	Description   string
-	SubOrDub      SubOrDub
-	Type          MediaFormat
-	Status        MediaStatus
-	OtherName     string
-	Genres        []string
-	TotalEpisodes int
-	Episodes      []Episode
+	ID            string      `json:"id"`
+	Title         string      `json:"title"`
+	URL           string      `json:"url"`
+	Image         string      `json:"image"`
+	ReleaseDate   string      `json:"releaseDate"`
+	Description   string      `json:"description"`
+	SubOrDub      SubOrDub    `json:"subOrDub"`
+	Type          MediaFormat `json:"type"`
+	Status        MediaStatus `json:"status"`
+	OtherName     string      `json:"otherName"`
+	Genres        []string    `json:"genres"`
+	TotalEpisodes int         `json:"totalEpisodes"`
+	Episodes      []Episode   `json:"episodes"`
+	Enrichment    *Enrichment `json:"enrichment,omitempty"`
 }
 
 type EpisodeServer struct {
-	Name string
-	URL  string
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type ReleaseRecord struct {
+	AnimeID       string    `json:"animeId"`
+	Title         string    `json:"title"`
+	Image         string    `json:"image"`
+	EpisodeNumber float64   `json:"episodeNumber"`
+	SubOrDub      SubOrDub  `json:"subOrDub"`
+	ReleasedAt    time.Time `json:"releasedAt"`
+}
+
+type Schedule struct {
+	Timezone string        `json:"timezone"`
+	Days     []ScheduleDay `json:"days"`
+}
+
+type ScheduleDay struct {
+	Day     string          `json:"day"`
+	Date    string          `json:"date"`
+	Entries []ScheduleEntry `json:"entries"`
+}
+
+type ScheduleEntry struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Image         string    `json:"image"`
+	SubOrDub      SubOrDub  `json:"subOrDub"`
+	Time          string    `json:"time"`
+	AiringAt      time.Time `json:"airingAt"`
+	LatestEpisode float64   `json:"latestEpisode"`
+	NextEpisode   float64   `json:"nextEpisode"`
+}
+
+type Mapping struct {
+	Provider   string    `json:"provider"`
+	ProviderID string    `json:"providerId"`
+	AniListID  int       `json:"anilistId"`
+	MalID      int       `json:"malId"`
+	KitsuID    int       `json:"kitsuId"`
+	Confidence float64   `json:"confidence"`
+	Manual     bool      `json:"manual"`
+	Confirmed  bool      `json:"confirmed"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type Enrichment struct {
+	AniListID         int            `json:"anilistId"`
+	MalID             int            `json:"malId"`
+	Format            string         `json:"format"`
+	Status            string         `json:"status"`
+	Season            string         `json:"season"`
+	SeasonYear        int            `json:"seasonYear"`
+	Episodes          int            `json:"episodes"`
+	Duration          int            `json:"duration"`
+	StartDate         string         `json:"startDate"`
+	EndDate           string         `json:"endDate"`
+	AverageScore      int            `json:"averageScore"`
+	MeanScore         int            `json:"meanScore"`
+	Popularity        int            `json:"popularity"`
+	BannerImage       string         `json:"bannerImage"`
+	CoverImage        string         `json:"coverImage"`
+	Color             string         `json:"color"`
+	Trailer           *Trailer       `json:"trailer"`
+	NextAiringEpisode *AiringEpisode `json:"nextAiringEpisode"`
+	Studios           []Studio       `json:"studios"`
+	Characters        []Character    `json:"characters"`
+	Relations         []Relation     `json:"relations"`
+}
+
+type Trailer struct {
+	ID        string `json:"id"`
+	Site      string `json:"site"`
+	URL       string `json:"url"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+type AiringEpisode struct {
+	Episode  int       `json:"episode"`
+	AiringAt time.Time `json:"airingAt"`
+}
+
+type Studio struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	IsMain bool   `json:"isMain"`
+}
+
+type Character struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Image       string       `json:"image"`
+	Role        string       `json:"role"`
+	VoiceActors []VoiceActor `json:"voiceActors"`
+}
+
+type VoiceActor struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Language string `json:"language"`
+}
+
+type Relation struct {
+	AniListID    int    `json:"anilistId"`
+	MalID        int    `json:"malId"`
+	RelationType string `json:"relationType"`
+	Type         string `json:"type"`
+	Format       string `json:"format"`
+	Title        string `json:"title"`
 }
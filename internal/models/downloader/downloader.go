@@ -1,25 +1,113 @@
 package downloader
 
+import "time"
+
+// Result is what every downloader resolves a link to, whatever the host.
+type Result struct {
+	Source    string `json:"source"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	Thumbnail string `json:"thumbnail,omitempty"`
+	Token     string `json:"token,omitempty"`
+	Files     []File `json:"files"`
+}
+
+type File struct {
+	URL      string `json:"url"`
+	Filename string `json:"filename,omitempty"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Quality  string `json:"quality,omitempty"`
+}
+
 type MediafireInfo struct {
-	URL      string
-	Filename string
-	Filetype string
-	Ext      string
-	Uploaded string
-	Filesize string
+	URL      string    `json:"url"`
+	Filename string    `json:"filename"`
+	Filetype string    `json:"filetype"`
+	Ext      string    `json:"ext"`
+	Uploaded time.Time `json:"uploaded"`
+	Filesize int64     `json:"filesize"`
+}
+
+type MediafireFolder struct {
+	Key     string            `json:"key"`
+	Name    string            `json:"name"`
+	Created time.Time         `json:"created"`
+	Files   []MediafireFile   `json:"files"`
+	Folders []MediafireFolder `json:"folders"`
+}
+
+type MediafireFile struct {
+	Key      string    `json:"key"`
+	Filename string    `json:"filename"`
+	MimeType string    `json:"mimeType"`
+	Filesize int64     `json:"filesize"`
+	Uploaded time.Time `json:"uploaded"`
+	Page     string    `json:"page"`
+	URL      string    `json:"url"`
 }
 
+type TiktokType string
+
+const (
+	TIKTOK_VIDEO     TiktokType = "VIDEO"
+	TIKTOK_SLIDESHOW TiktokType = "SLIDESHOW"
+)
+
 type TiktokResult struct {
-	Nickname    string
-	Username    string
-	Avatar      string
-	Description string
-	Thumbnail   string
-	Played      string
-	Commented   string
-	Saved       string
-	Shared      string
-	Song        string
-	Video       string
-	Audio       string
+	ID             string     `json:"id"`
+	Type           TiktokType `json:"type"`
+	Provider       string     `json:"provider"`
+	Nickname       string     `json:"nickname"`
+	Username       string     `json:"username"`
+	Avatar         string     `json:"avatar"`
+	Description    string     `json:"description"`
+	Hashtags       []string   `json:"hashtags"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	Thumbnail      string     `json:"thumbnail"`
+	Played         int64      `json:"played"`
+	Commented      int64      `json:"commented"`
+	Saved          int64      `json:"saved"`
+	Shared         int64      `json:"shared"`
+	Song           string     `json:"song"`
+	Video          string     `json:"video"`
+	VideoHD        string     `json:"videoHd,omitempty"`
+	VideoWatermark string     `json:"videoWatermark,omitempty"`
+	Audio          string     `json:"audio"`
+	Images         []string   `json:"images"`
+}
+
+// SocialPost is a post on a short-video or social platform, with every media
+// variant the platform offers for it.
+type SocialPost struct {
+	ID        string         `json:"id"`
+	Platform  string         `json:"platform"`
+	URL       string         `json:"url"`
+	Author    SocialAuthor   `json:"author"`
+	Caption   string         `json:"caption"`
+	Hashtags  []string       `json:"hashtags"`
+	Thumbnail string         `json:"thumbnail"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Liked     int64          `json:"liked"`
+	Commented int64          `json:"commented"`
+	Played    int64          `json:"played"`
+	Shared    int64          `json:"shared"`
+	Videos    []MediaVariant `json:"videos"`
+	Images    []MediaVariant `json:"images"`
+}
+
+type SocialAuthor struct {
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	URL      string `json:"url,omitempty"`
+}
+
+type MediaVariant struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mimeType"`
+	Quality  string `json:"quality,omitempty"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Bitrate  int    `json:"bitrate,omitempty"`
 }
@@ -1,19 +1,58 @@
 package manga
 
+import "time"
+
 type MangaInfo struct {
-    ID            string
-    Title         string
-    AltTitles     any
-    Description   string
-    Status        string
-    ReleaseDate   int
-    ContentRating string
-    LastVolume    string
-    LastChapter   string
-    Image         string
+	ID            string              `json:"id"`
+	Title         string              `json:"title"`
+	AltTitles     []map[string]string `json:"altTitles"`
+	Description   string              `json:"description"`
+	Status        string              `json:"status"`
+	ReleaseDate   int                 `json:"releaseDate"`
+	ContentRating string              `json:"contentRating"`
+	LastVolume    string              `json:"lastVolume"`
+	LastChapter   string              `json:"lastChapter"`
+	Image         string              `json:"image"`
+	Thumbnails    map[string]string   `json:"thumbnails"`
+	Authors       []Creator           `json:"authors"`
+	Artists       []Creator           `json:"artists"`
+}
+
+type Creator struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type SearchResults struct {
-	CurrentPage int           
-	Results     []MangaInfo
+	CurrentPage int         `json:"currentPage"`
+	HasNextPage bool        `json:"hasNextPage"`
+	Total       int         `json:"total"`
+	Results     []MangaInfo `json:"results"`
+}
+
+func (r SearchResults) Pagination() (page, limit, total int, hasNext bool) {
+	return r.CurrentPage, 0, r.Total, r.HasNextPage
+}
+
+func (r SearchResults) Items() any {
+	return r.Results
+}
+
+type Tag struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Group string `json:"group"`
+}
+
+type Chapter struct {
+	ID         string    `json:"id"`
+	MangaID    string    `json:"mangaId"`
+	MangaTitle string    `json:"mangaTitle"`
+	Title      string    `json:"title"`
+	Volume     string    `json:"volume"`
+	Chapter    string    `json:"chapter"`
+	Language   string    `json:"language"`
+	Pages      int       `json:"pages"`
+	PublishAt  time.Time `json:"publishAt"`
+	Groups     []string  `json:"groups"`
 }
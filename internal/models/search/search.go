@@ -0,0 +1,43 @@
+package search
+
+type Kind string
+
+const (
+	ANIME Kind = "ANIME"
+	MANGA Kind = "MANGA"
+)
+
+type Result struct {
+	Kind   Kind    `json:"kind"`
+	Source string  `json:"source"`
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Image  string  `json:"image"`
+	Year   int     `json:"year"`
+	Score  float64 `json:"score"`
+}
+
+type SourceError struct {
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+type Results struct {
+	Query   string        `json:"query"`
+	Results []Result      `json:"results"`
+	Errors  []SourceError `json:"errors"`
+}
+
+type CatalogResults struct {
+	CurrentPage int      `json:"currentPage"`
+	HasNextPage bool     `json:"hasNextPage"`
+	Results     []Result `json:"results"`
+}
+
+func (r CatalogResults) Pagination() (page, limit, total int, hasNext bool) {
+	return r.CurrentPage, 0, 0, r.HasNextPage
+}
+
+func (r CatalogResults) Items() any {
+	return r.Results
+}
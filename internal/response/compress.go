@@ -0,0 +1,288 @@
+package response
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Encodings are the content codings CompressWriter can apply, in order of
+// preference when a client accepts several equally.
+var Encodings = []string{"br", "gzip"}
+
+// CompressWriter buffers a response so it can be given a strong ETag,
+// answered with 304 Not Modified when the client already has it, and
+// compressed according to Accept-Encoding. Weak ETags set by the handler are
+// kept and checked the same way. Streams, media and archives, responses with
+// their own strong validators and anything flushed early are passed through
+// untouched, as are hijacked connections. Close must be called once the
+// handler returns.
+type CompressWriter struct {
+	r           *http.Request
+	wrapped     http.ResponseWriter
+	minSize     int
+	statusCode  int
+	buf         bytes.Buffer
+	decided     bool
+	passthrough bool
+}
+
+func NewCompressWriter(w http.ResponseWriter, r *http.Request, minSize int) *CompressWriter {
+	return &CompressWriter{
+		r:          r,
+		wrapped:    w,
+		minSize:    minSize,
+		statusCode: http.StatusOK,
+	}
+}
+
+func (cw *CompressWriter) Header() http.Header {
+	return cw.wrapped.Header()
+}
+
+func (cw *CompressWriter) WriteHeader(statusCode int) {
+	if cw.decided {
+		return
+	}
+
+	cw.statusCode = statusCode
+	cw.decide()
+}
+
+func (cw *CompressWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		cw.decide()
+	}
+
+	if cw.passthrough {
+		return cw.wrapped.Write(b)
+	}
+
+	return cw.buf.Write(b)
+}
+
+// Flush switches to passthrough, writing out anything buffered so far, so
+// that handlers which stream keep working.
+func (cw *CompressWriter) Flush() {
+	if !cw.decided {
+		cw.decide()
+	}
+
+	if !cw.passthrough {
+		cw.passthrough = true
+		cw.wrapped.WriteHeader(cw.statusCode)
+		cw.wrapped.Write(cw.buf.Bytes())
+		cw.buf.Reset()
+	}
+
+	http.NewResponseController(cw.wrapped).Flush()
+}
+
+func (cw *CompressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	cw.decided = true
+	cw.passthrough = true
+
+	return http.NewResponseController(cw.wrapped).Hijack()
+}
+
+func (cw *CompressWriter) Unwrap() http.ResponseWriter {
+	return cw.wrapped
+}
+
+func (cw *CompressWriter) decide() {
+	cw.decided = true
+
+	h := cw.wrapped.Header()
+	if h.Get("Content-Type") == "" || !compressible(h.Get("Content-Type")) || h.Get("Content-Encoding") != "" || strongETag(h.Get("ETag")) {
+		cw.passthrough = true
+		cw.wrapped.WriteHeader(cw.statusCode)
+	}
+}
+
+// Close finishes a buffered response.
+func (cw *CompressWriter) Close() error {
+	if !cw.decided {
+		cw.decide()
+	}
+
+	if cw.passthrough {
+		return nil
+	}
+
+	h := cw.wrapped.Header()
+	body := cw.buf.Bytes()
+
+	encoding := ""
+	if len(body) >= cw.minSize && bodyAllowed(cw.statusCode) {
+		h.Add("Vary", "Accept-Encoding")
+		encoding = negotiateEncoding(cw.r.Header.Get("Accept-Encoding"))
+	}
+
+	if cw.statusCode == http.StatusOK && (cw.r.Method == http.MethodGet || cw.r.Method == http.MethodHead) {
+		etag := h.Get("ETag")
+		if etag == "" {
+			sum := sha256.Sum256(body)
+			etag = hex.EncodeToString(sum[:16])
+			if encoding != "" {
+				etag += "-" + encoding
+			}
+			etag = strconv.Quote(etag)
+			h.Set("ETag", etag)
+		}
+
+		if notModified(cw.r, etag, h.Get("Last-Modified")) {
+			h.Del("Content-Type")
+			h.Del("Content-Length")
+			cw.wrapped.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+
+	if encoding != "" {
+		var compressed bytes.Buffer
+
+		err := compress(&compressed, encoding, body)
+		if err != nil {
+			return err
+		}
+
+		body = compressed.Bytes()
+		h.Set("Content-Encoding", encoding)
+	}
+
+	if bodyAllowed(cw.statusCode) {
+		h.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	cw.wrapped.WriteHeader(cw.statusCode)
+	_, err := cw.wrapped.Write(body)
+	return err
+}
+
+func strongETag(etag string) bool {
+	return etag != "" && !strings.HasPrefix(etag, "W/")
+}
+
+func compress(w io.Writer, encoding string, body []byte) error {
+	var zw io.WriteCloser
+
+	switch encoding {
+	case "br":
+		zw = brotli.NewWriterLevel(w, brotli.DefaultCompression)
+	default:
+		zw = gzip.NewWriter(w)
+	}
+
+	_, err := zw.Write(body)
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/xml", "application/msgpack", "application/javascript":
+		return true
+	}
+
+	return false
+}
+
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// notModified reports whether the client's copy is current. If-None-Match
+// takes precedence over If-Modified-Since and uses the weak comparison, as in
+// RFC 9110.
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag = strings.TrimPrefix(etag, "W/")
+
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified == "" {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !modified.Truncate(time.Second).After(since)
+}
+
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		qualities[coding] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range Encodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,360 @@
+package response
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Encoder writes a response tree (see Tree) in one wire format.
+type Encoder struct {
+	Name        string
+	ContentType string
+	MediaTypes  []string
+	Encode      func(w io.Writer, tree any) error
+}
+
+var (
+	mu       sync.RWMutex
+	encoders = []Encoder{
+		{Name: "json", ContentType: "application/json", MediaTypes: []string{"application/json"}, Encode: encodeJSON},
+		{Name: "compact", ContentType: "application/json", Encode: encodeCompactJSON},
+		{Name: "msgpack", ContentType: "application/msgpack", MediaTypes: []string{"application/msgpack", "application/x-msgpack"}, Encode: encodeMsgpack},
+		{Name: "xml", ContentType: "application/xml", MediaTypes: []string{"application/xml", "text/xml"}, Encode: encodeXML},
+		{Name: "csv", ContentType: "text/csv", MediaTypes: []string{"text/csv"}, Encode: encodeCSV},
+	}
+)
+
+// Register adds an encoder, replacing any existing encoder with the same name.
+func Register(e Encoder) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range encoders {
+		if encoders[i].Name == e.Name {
+			encoders[i] = e
+			return
+		}
+	}
+
+	encoders = append(encoders, e)
+}
+
+func Encoders() []Encoder {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]Encoder(nil), encoders...)
+}
+
+// Negotiate picks the encoder for a request. A format query parameter naming
+// an encoder wins; otherwise the Accept header is matched in order of
+// preference. Pretty-printed JSON is the default. ok is false only when the
+// format parameter names an unknown encoder.
+func Negotiate(r *http.Request) (Encoder, bool) {
+	all := Encoders()
+
+	if format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))); format != "" {
+		for _, e := range all {
+			if e.Name == format {
+				return e, true
+			}
+		}
+
+		return all[0], false
+	}
+
+	for _, mediaType := range acceptedMediaTypes(r.Header.Get("Accept")) {
+		for _, e := range all {
+			for _, t := range e.MediaTypes {
+				if t == mediaType {
+					return e, true
+				}
+			}
+		}
+	}
+
+	return all[0], true
+}
+
+func acceptedMediaTypes(accept string) []string {
+	type accepted struct {
+		mediaType string
+		q         float64
+	}
+
+	var types []accepted
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(s, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if q > 0 {
+			types = append(types, accepted{mediaType: mediaType, q: q})
+		}
+	}
+
+	sort.SliceStable(types, func(i, j int) bool { return types[i].q > types[j].q })
+
+	mediaTypes := make([]string, len(types))
+	for i, t := range types {
+		mediaTypes[i] = t.mediaType
+	}
+
+	return mediaTypes
+}
+
+// write encodes a response tree with the encoder negotiated for r.
+func write(w http.ResponseWriter, r *http.Request, status int, tree any, headers http.Header) error {
+	enc, _ := Negotiate(r)
+
+	var buf bytes.Buffer
+	err := enc.Encode(&buf, tree)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		w.Header()[key] = value
+	}
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", enc.ContentType)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+
+	return nil
+}
+
+func encodeJSON(w io.Writer, tree any) error {
+	js, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(js, '\n'))
+	return err
+}
+
+func encodeCompactJSON(w io.Writer, tree any) error {
+	js, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(js, '\n'))
+	return err
+}
+
+// encodeXML writes objects as elements named after their keys and list items
+// as <item> elements. Keys which aren't valid element names, such as language
+// codes or sizes used as map keys, become <item key="...">.
+func encodeXML(w io.Writer, tree any) error {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	err = encodeXMLElement(enc, "response", tree)
+	if err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+func encodeXMLElement(enc *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !isXMLName(name) {
+		start = xml.StartElement{
+			Name: xml.Name{Local: "item"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+
+	err := enc.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case Object:
+		for _, field := range v {
+			err := encodeXMLElement(enc, field.Key, field.Value)
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			err := encodeXMLElement(enc, "item", item)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		err := enc.EncodeToken(xml.CharData(scalarString(v)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r == '-' || r == '.' || r >= '0' && r <= '9'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// encodeCSV writes the list in a response as rows, one column per field with
+// nested fields flattened to dotted names. The list is the data itself for v2
+// responses, or the first list of objects found in it otherwise (such as the
+// results of a search or the episodes of an anime). A response without a list
+// is written as a single row.
+func encodeCSV(w io.Writer, tree any) error {
+	rows := csvRows(tree)
+
+	var columns []string
+	seen := map[string]bool{}
+	flat := make([]map[string]string, len(rows))
+
+	for i, row := range rows {
+		flat[i] = map[string]string{}
+		flattenCSV("", row, flat[i], func(column string) {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		})
+	}
+
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(columns)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range flat {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+
+		err := cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRows(tree any) []any {
+	if obj, ok := tree.(Object); ok {
+		for _, field := range obj {
+			if field.Key == "data" || field.Key == "Message" {
+				tree = field.Value
+				break
+			}
+		}
+	}
+
+	switch v := tree.(type) {
+	case []any:
+		return v
+	case Object:
+		for _, field := range v {
+			if items, ok := field.Value.([]any); ok && len(items) > 0 {
+				if _, ok := items[0].(Object); ok {
+					return items
+				}
+			}
+		}
+	}
+
+	return []any{tree}
+}
+
+func flattenCSV(prefix string, value any, row map[string]string, addColumn func(string)) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case Object:
+		for _, field := range v {
+			key := field.Key
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenCSV(key, field.Value, row, addColumn)
+		}
+		return
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.(Object); ok {
+				js, _ := json.Marshal(item)
+				parts = append(parts, string(js))
+			} else {
+				parts = append(parts, scalarString(item))
+			}
+		}
+		value = strings.Join(parts, "; ")
+	}
+
+	if prefix == "" {
+		prefix = "value"
+	}
+
+	addColumn(prefix)
+	row[prefix] = scalarString(value)
+}
+
+func scalarString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,142 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type Version int
+
+const (
+	V1 Version = 1
+	V2 Version = 2
+)
+
+// Envelope is the shape of every v2 response. Exactly one of Data and Error
+// is set.
+type Envelope struct {
+	Status string `json:"status"`
+	Data   any    `json:"data,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+	Meta   Meta   `json:"meta"`
+}
+
+type Error struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+type Meta struct {
+	Page      int    `json:"page,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+	Total     int    `json:"total,omitempty"`
+	HasNext   *bool  `json:"hasNext,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// Paginated is implemented by results which are one page of a longer list. In
+// v2 responses their items become the data and their position goes in meta.
+type Paginated interface {
+	Pagination() (page, limit, total int, hasNext bool)
+	Items() any
+}
+
+// VersionOf returns the API version a request was made against. Paths under
+// /v2 get the v2 envelope; everything else, including /v1 and the original
+// unprefixed paths, keeps the v1 format.
+func VersionOf(r *http.Request) Version {
+	if r.URL.Path == "/v2" || strings.HasPrefix(r.URL.Path, "/v2/") {
+		return V2
+	}
+
+	return V1
+}
+
+type contextKey string
+
+const requestIDContextKey = contextKey("requestID")
+
+func ContextSetRequestID(r *http.Request, id string) *http.Request {
+	ctx := context.WithValue(r.Context(), requestIDContextKey, id)
+	return r.WithContext(ctx)
+}
+
+func RequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDContextKey).(string)
+	return id
+}
+
+// Data writes a successful response in the format of the request's API
+// version.
+func Data(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	return DataWithHeaders(w, r, status, data, nil)
+}
+
+func DataWithHeaders(w http.ResponseWriter, r *http.Request, status int, data any, headers http.Header) error {
+	if VersionOf(r) == V1 {
+		legacy := map[string]any{
+			"Status":  "OK",
+			"Message": data,
+		}
+
+		return write(w, r, status, Tree(legacy, true), headers)
+	}
+
+	// The request ID is left to the X-Request-ID header so that identical
+	// responses are byte for byte the same and get the same ETag.
+	envelope := Envelope{
+		Status: "ok",
+		Data:   data,
+	}
+
+	if p, ok := data.(Paginated); ok {
+		page, limit, total, hasNext := p.Pagination()
+
+		envelope.Data = p.Items()
+		envelope.Meta.Page = page
+		envelope.Meta.Limit = limit
+		envelope.Meta.Total = total
+		envelope.Meta.HasNext = &hasNext
+	}
+
+	return write(w, r, status, Tree(envelope, false), headers)
+}
+
+// Unwrapped writes data in the negotiated format without an envelope, for
+// responses whose shape is fixed elsewhere, such as GraphQL results.
+func Unwrapped(w http.ResponseWriter, r *http.Request, status int, data any) error {
+	return write(w, r, status, Tree(data, false), nil)
+}
+
+// Failure writes an error response. code is a short machine-readable name for
+// the error, used by v2 clients. v1 responses carry only the message, or the
+// legacy validator for validation failures.
+func Failure(w http.ResponseWriter, r *http.Request, status int, e Error, legacy any, headers http.Header) error {
+	if VersionOf(r) == V1 {
+		if legacy == nil {
+			legacy = e.Message
+		}
+
+		data := map[string]any{
+			"Status":  "ERROR",
+			"Message": legacy,
+		}
+
+		return write(w, r, status, Tree(data, true), headers)
+	}
+
+	envelope := Envelope{
+		Status: "error",
+		Error:  &e,
+		Meta:   Meta{RequestID: RequestID(r)},
+	}
+
+	return write(w, r, status, Tree(envelope, false), headers)
+}
+
+// ErrorCode is the default error code for a status, e.g. "not_found".
+func ErrorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
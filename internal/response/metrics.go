@@ -1,6 +1,10 @@
 package response
 
-import "net/http"
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
 
 type MetricsResponseWriter struct {
 	StatusCode    int
@@ -40,3 +44,15 @@ func (mw *MetricsResponseWriter) Write(b []byte) (int, error) {
 func (mw *MetricsResponseWriter) Unwrap() http.ResponseWriter {
 	return mw.wrapped
 }
+
+// Hijack lets connections be taken over, such as for WebSockets, which are
+// recorded as switching protocols.
+func (mw *MetricsResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(mw.wrapped).Hijack()
+	if err == nil && !mw.headerWritten {
+		mw.StatusCode = http.StatusSwitchingProtocols
+		mw.headerWritten = true
+	}
+
+	return conn, brw, err
+}
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WantsEventStream reports whether the client asked for a Server-Sent Events
+// stream rather than a single response.
+func WantsEventStream(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == "text/event-stream" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EventStream writes Server-Sent Events. Event data is JSON named the same
+// way as other responses for the request's API version.
+type EventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	goNames bool
+}
+
+// NewEventStream sends the stream's headers. The server's write timeout is
+// lifted for the connection, as a stream lasts as long as it needs to.
+func NewEventStream(w http.ResponseWriter, r *http.Request) (*EventStream, error) {
+	rc := http.NewResponseController(w)
+
+	err := rc.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	s := &EventStream{w: w, rc: rc, goNames: VersionOf(r) == V1}
+	return s, rc.Flush()
+}
+
+func (s *EventStream) Send(event string, data any) error {
+	js, err := json.Marshal(Tree(data, s.goNames))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, js)
+	if err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
+
+// Heartbeat sends a heartbeat event, which keeps proxies from closing an idle
+// stream and lets clients tell a slow upstream from a dead connection.
+func (s *EventStream) Heartbeat() error {
+	_, err := fmt.Fprintf(s.w, "event: heartbeat\ndata: {}\n\n")
+	if err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
@@ -0,0 +1,222 @@
+package response
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Object is an encoded struct or map. Unlike a map it keeps its fields in
+// order, so struct fields come out in the order they are declared.
+type Object []Field
+
+type Field struct {
+	Key   string
+	Value any
+}
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Tree turns v into a tree made only of nil, bools, numbers, strings, []any
+// and Object, following the same rules as encoding/json. With goNames set,
+// struct fields are named after the Go field rather than their json tag,
+// which is how the v1 API has always named them.
+func Tree(v any, goNames bool) any {
+	return tree(reflect.ValueOf(v), goNames)
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func tree(v reflect.Value, goNames bool) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano)
+	}
+
+	if _, ok := v.Interface().(Object); ok {
+		return v.Interface()
+	}
+
+	if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface && v.Type().Implements(jsonMarshalerType) {
+		js, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil
+		}
+
+		var decoded any
+		if err := json.Unmarshal(js, &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return tree(v.Elem(), goNames)
+
+	case reflect.Bool:
+		return v.Bool()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+
+	case reflect.String:
+		return v.String()
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = tree(v.Index(i), goNames)
+		}
+		return items
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		obj := make(Object, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			obj = append(obj, Field{Key: mapKey(iter.Key()), Value: tree(iter.Value(), goNames)})
+		}
+
+		sort.Slice(obj, func(i, j int) bool { return obj[i].Key < obj[j].Key })
+		return obj
+
+	case reflect.Struct:
+		obj := Object{}
+		structFields(v, goNames, &obj)
+		return obj
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func structFields(v reflect.Value, goNames bool, obj *Object) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		value := v.Field(i)
+
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+
+			if value.Kind() == reflect.Struct {
+				structFields(value, goNames, obj)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" || goNames {
+			name = field.Name
+		}
+
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyValue(value) {
+			continue
+		}
+
+		*obj = append(*obj, Field{Key: name, Value: tree(value, goNames)})
+	}
+}
+
+func mapKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+
+	if k.Type().Implements(textMarshalerType) {
+		text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+		if err == nil {
+			return string(text)
+		}
+	}
+
+	return fmt.Sprint(k.Interface())
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+
+	return false
+}
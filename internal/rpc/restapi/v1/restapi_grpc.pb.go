@@ -0,0 +1,413 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: restapi/v1/restapi.proto
+
+package restapiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnimeService_Search_FullMethodName  = "/restapi.v1.AnimeService/Search"
+	AnimeService_Info_FullMethodName    = "/restapi.v1.AnimeService/Info"
+	AnimeService_Servers_FullMethodName = "/restapi.v1.AnimeService/Servers"
+)
+
+// AnimeServiceClient is the client API for AnimeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnimeService serves the same anime data as the /anime REST endpoints.
+type AnimeServiceClient interface {
+	Search(ctx context.Context, in *AnimeSearchRequest, opts ...grpc.CallOption) (*AnimeSearchResponse, error)
+	Info(ctx context.Context, in *AnimeInfoRequest, opts ...grpc.CallOption) (*AnimeInfo, error)
+	Servers(ctx context.Context, in *EpisodeServersRequest, opts ...grpc.CallOption) (*EpisodeServersResponse, error)
+}
+
+type animeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnimeServiceClient(cc grpc.ClientConnInterface) AnimeServiceClient {
+	return &animeServiceClient{cc}
+}
+
+func (c *animeServiceClient) Search(ctx context.Context, in *AnimeSearchRequest, opts ...grpc.CallOption) (*AnimeSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnimeSearchResponse)
+	err := c.cc.Invoke(ctx, AnimeService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animeServiceClient) Info(ctx context.Context, in *AnimeInfoRequest, opts ...grpc.CallOption) (*AnimeInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnimeInfo)
+	err := c.cc.Invoke(ctx, AnimeService_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animeServiceClient) Servers(ctx context.Context, in *EpisodeServersRequest, opts ...grpc.CallOption) (*EpisodeServersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EpisodeServersResponse)
+	err := c.cc.Invoke(ctx, AnimeService_Servers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnimeServiceServer is the server API for AnimeService service.
+// All implementations must embed UnimplementedAnimeServiceServer
+// for forward compatibility.
+//
+// AnimeService serves the same anime data as the /anime REST endpoints.
+type AnimeServiceServer interface {
+	Search(context.Context, *AnimeSearchRequest) (*AnimeSearchResponse, error)
+	Info(context.Context, *AnimeInfoRequest) (*AnimeInfo, error)
+	Servers(context.Context, *EpisodeServersRequest) (*EpisodeServersResponse, error)
+	mustEmbedUnimplementedAnimeServiceServer()
+}
+
+// UnimplementedAnimeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnimeServiceServer struct{}
+
+func (UnimplementedAnimeServiceServer) Search(context.Context, *AnimeSearchRequest) (*AnimeSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedAnimeServiceServer) Info(context.Context, *AnimeInfoRequest) (*AnimeInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedAnimeServiceServer) Servers(context.Context, *EpisodeServersRequest) (*EpisodeServersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Servers not implemented")
+}
+func (UnimplementedAnimeServiceServer) mustEmbedUnimplementedAnimeServiceServer() {}
+func (UnimplementedAnimeServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAnimeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnimeServiceServer will
+// result in compilation errors.
+type UnsafeAnimeServiceServer interface {
+	mustEmbedUnimplementedAnimeServiceServer()
+}
+
+func RegisterAnimeServiceServer(s grpc.ServiceRegistrar, srv AnimeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnimeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnimeService_ServiceDesc, srv)
+}
+
+func _AnimeService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnimeSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimeServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimeService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimeServiceServer).Search(ctx, req.(*AnimeSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimeService_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnimeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimeServiceServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimeService_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimeServiceServer).Info(ctx, req.(*AnimeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimeService_Servers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EpisodeServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimeServiceServer).Servers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimeService_Servers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimeServiceServer).Servers(ctx, req.(*EpisodeServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnimeService_ServiceDesc is the grpc.ServiceDesc for AnimeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnimeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.AnimeService",
+	HandlerType: (*AnimeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _AnimeService_Search_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _AnimeService_Info_Handler,
+		},
+		{
+			MethodName: "Servers",
+			Handler:    _AnimeService_Servers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "restapi/v1/restapi.proto",
+}
+
+const (
+	MangaService_Search_FullMethodName = "/restapi.v1.MangaService/Search"
+)
+
+// MangaServiceClient is the client API for MangaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MangaService serves the same manga data as the /manga REST endpoints.
+type MangaServiceClient interface {
+	Search(ctx context.Context, in *MangaSearchRequest, opts ...grpc.CallOption) (*MangaSearchResponse, error)
+}
+
+type mangaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMangaServiceClient(cc grpc.ClientConnInterface) MangaServiceClient {
+	return &mangaServiceClient{cc}
+}
+
+func (c *mangaServiceClient) Search(ctx context.Context, in *MangaSearchRequest, opts ...grpc.CallOption) (*MangaSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MangaSearchResponse)
+	err := c.cc.Invoke(ctx, MangaService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MangaServiceServer is the server API for MangaService service.
+// All implementations must embed UnimplementedMangaServiceServer
+// for forward compatibility.
+//
+// MangaService serves the same manga data as the /manga REST endpoints.
+type MangaServiceServer interface {
+	Search(context.Context, *MangaSearchRequest) (*MangaSearchResponse, error)
+	mustEmbedUnimplementedMangaServiceServer()
+}
+
+// UnimplementedMangaServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMangaServiceServer struct{}
+
+func (UnimplementedMangaServiceServer) Search(context.Context, *MangaSearchRequest) (*MangaSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedMangaServiceServer) mustEmbedUnimplementedMangaServiceServer() {}
+func (UnimplementedMangaServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMangaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MangaServiceServer will
+// result in compilation errors.
+type UnsafeMangaServiceServer interface {
+	mustEmbedUnimplementedMangaServiceServer()
+}
+
+func RegisterMangaServiceServer(s grpc.ServiceRegistrar, srv MangaServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMangaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MangaService_ServiceDesc, srv)
+}
+
+func _MangaService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MangaSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).Search(ctx, req.(*MangaSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MangaService_ServiceDesc is the grpc.ServiceDesc for MangaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MangaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.MangaService",
+	HandlerType: (*MangaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _MangaService_Search_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "restapi/v1/restapi.proto",
+}
+
+const (
+	DownloaderService_Resolve_FullMethodName = "/restapi.v1.DownloaderService/Resolve"
+)
+
+// DownloaderServiceClient is the client API for DownloaderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DownloaderService resolves links the way /downloader does.
+type DownloaderServiceClient interface {
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*DownloadResult, error)
+}
+
+type downloaderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDownloaderServiceClient(cc grpc.ClientConnInterface) DownloaderServiceClient {
+	return &downloaderServiceClient{cc}
+}
+
+func (c *downloaderServiceClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*DownloadResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadResult)
+	err := c.cc.Invoke(ctx, DownloaderService_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DownloaderServiceServer is the server API for DownloaderService service.
+// All implementations must embed UnimplementedDownloaderServiceServer
+// for forward compatibility.
+//
+// DownloaderService resolves links the way /downloader does.
+type DownloaderServiceServer interface {
+	Resolve(context.Context, *ResolveRequest) (*DownloadResult, error)
+	mustEmbedUnimplementedDownloaderServiceServer()
+}
+
+// UnimplementedDownloaderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDownloaderServiceServer struct{}
+
+func (UnimplementedDownloaderServiceServer) Resolve(context.Context, *ResolveRequest) (*DownloadResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedDownloaderServiceServer) mustEmbedUnimplementedDownloaderServiceServer() {}
+func (UnimplementedDownloaderServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDownloaderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DownloaderServiceServer will
+// result in compilation errors.
+type UnsafeDownloaderServiceServer interface {
+	mustEmbedUnimplementedDownloaderServiceServer()
+}
+
+func RegisterDownloaderServiceServer(s grpc.ServiceRegistrar, srv DownloaderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDownloaderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DownloaderService_ServiceDesc, srv)
+}
+
+func _DownloaderService_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServiceServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DownloaderService_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServiceServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DownloaderService_ServiceDesc is the grpc.ServiceDesc for DownloaderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DownloaderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "restapi.v1.DownloaderService",
+	HandlerType: (*DownloaderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Resolve",
+			Handler:    _DownloaderService_Resolve_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "restapi/v1/restapi.proto",
+}